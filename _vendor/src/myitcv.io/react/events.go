@@ -13,3 +13,15 @@ type OnClick interface {
 
 	OnClick(e *SyntheticMouseEvent)
 }
+
+type OnLoad interface {
+	Event
+
+	OnLoad(e *SyntheticEvent)
+}
+
+type OnError interface {
+	Event
+
+	OnError(e *SyntheticEvent)
+}
@@ -0,0 +1,78 @@
+// Code generated by reactGen. DO NOT EDIT.
+
+package react
+
+// ImgProps defines the properties for the <img> element
+type ImgProps struct {
+	Alt                     string
+	ClassName               string
+	DangerouslySetInnerHTML *DangerousInnerHTMLDef
+	Draggable               string
+	Height                  string
+	ID                      string
+	Key                     string
+
+	OnChange
+	OnClick
+	OnError
+	OnLoad
+
+	Role  string
+	Src   string
+	Style *CSS
+	Width string
+}
+
+func (i *ImgProps) assign(v *_ImgProps) {
+
+	v.Alt = i.Alt
+
+	v.ClassName = i.ClassName
+
+	v.DangerouslySetInnerHTML = i.DangerouslySetInnerHTML
+
+	if i.Draggable != "" {
+		v.Draggable = i.Draggable
+	}
+
+	if i.Height != "" {
+		v.Height = i.Height
+	}
+
+	if i.ID != "" {
+		v.ID = i.ID
+	}
+
+	if i.Key != "" {
+		v.Key = i.Key
+	}
+
+	if i.OnChange != nil {
+		v.o.Set("onChange", i.OnChange.OnChange)
+	}
+
+	if i.OnClick != nil {
+		v.o.Set("onClick", i.OnClick.OnClick)
+	}
+
+	if i.OnError != nil {
+		v.o.Set("onError", i.OnError.OnError)
+	}
+
+	if i.OnLoad != nil {
+		v.o.Set("onLoad", i.OnLoad.OnLoad)
+	}
+
+	v.Role = i.Role
+
+	v.Src = i.Src
+
+	// TODO: until we have a resolution on
+	// https://github.com/gopherjs/gopherjs/issues/236
+	v.Style = i.Style.hack()
+
+	if i.Width != "" {
+		v.Width = i.Width
+	}
+
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package react
+
+import "github.com/gopherjs/gopherjs/js"
+
+// ImgDef is the React component definition corresponding to the HTML <img> element
+type ImgDef struct {
+	underlying *js.Object
+}
+
+// _ImgProps defines the properties for the <img> element
+type _ImgProps struct {
+	*BasicHTMLElement
+
+	Src       string `js:"src"`
+	Alt       string `js:"alt"`
+	Width     string `js:"width" react:"omitempty"`
+	Height    string `js:"height" react:"omitempty"`
+	Draggable string `js:"draggable" react:"omitempty"`
+
+	OnLoad  `js:"onLoad"`
+	OnError `js:"onError"`
+}
+
+func (d *ImgDef) reactElement() {}
+
+// Img creates a new instance of a <img> element with the provided props
+func Img(props *ImgProps) *ImgDef {
+
+	rProps := &_ImgProps{
+		BasicHTMLElement: newBasicHTMLElement(),
+	}
+
+	if props != nil {
+		props.assign(rProps)
+	}
+
+	args := []interface{}{"img", rProps}
+
+	underlying := react.Call("createElement", args...)
+
+	return &ImgDef{
+		underlying: underlying,
+	}
+}
@@ -0,0 +1,22 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+// Package artwork is the artwork from which gophers are built, and its
+// manifest.
+//
+// Each NNN-Category directory beneath this package's directory is a category
+// of layers; the numeric prefix determines the z-order of the category (lower
+// numbers are drawn first). Within a category, each foo.png is an option and
+// is paired with a foo_thumbnail.png used by the picker.
+//
+// The manifest, Default, is generated by manifestGen; run go generate after
+// adding or removing artwork. Its types are defined by package gopher, so that
+// code that only needs to reason about gophers need not depend on the
+// artwork.
+package artwork
+
+//go:generate manifestGen
+
+// NoneThumbnail is the slash-separated path, relative to the artwork root, of
+// the thumbnail that represents no option being selected
+const NoneThumbnail = "whitebox_thumbnail.png"
@@ -0,0 +1,571 @@
+// Code generated by manifestGen. DO NOT EDIT.
+
+package artwork
+
+import "github.com/myitcv/gopherize.me/gopher"
+
+// Default is the manifest of the artwork in this directory
+var Default = &gopher.Manifest{
+	Version: "736836db65466eda",
+	Categories: []*gopher.Category{
+		{
+			ID:    "body",
+			Name:  "Body",
+			Dir:   "010-Body",
+			Order: 10,
+			Options: []*gopher.Option{
+				{ID: "blue_gopher", Name: "Blue Gopher", Image: "010-Body/blue_gopher.png", Thumbnail: "010-Body/blue_gopher_thumbnail.png", Colour: "abc3d6"},
+				{ID: "blue_spike_hair", Name: "Blue Spike Hair", Image: "010-Body/blue_spike_hair.png", Thumbnail: "010-Body/blue_spike_hair_thumbnail.png"},
+				{ID: "brown_gopher", Name: "Brown Gopher", Image: "010-Body/brown_gopher.png", Thumbnail: "010-Body/brown_gopher_thumbnail.png", Colour: "c4ae91"},
+				{ID: "green_gopher", Name: "Green Gopher", Image: "010-Body/green_gopher.png", Thumbnail: "010-Body/green_gopher_thumbnail.png", Colour: "abd6b3"},
+				{ID: "pink_gopher", Name: "Pink Gopher", Image: "010-Body/pink_gopher.png", Thumbnail: "010-Body/pink_gopher_thumbnail.png", Colour: "ffcaca"},
+				{ID: "purple_gopher", Name: "Purple Gopher", Image: "010-Body/purple_gopher.png", Thumbnail: "010-Body/purple_gopher_thumbnail.png", Colour: "c2a1d3"},
+			},
+		},
+		{
+			ID:    "eyes",
+			Name:  "Eyes",
+			Dir:   "020-Eyes",
+			Order: 20,
+			Options: []*gopher.Option{
+				{ID: "crazy_eyes", Name: "Crazy Eyes", Image: "020-Eyes/crazy_eyes.png", Thumbnail: "020-Eyes/crazy_eyes_thumbnail.png"},
+				{ID: "eyelashes", Name: "Eyelashes", Image: "020-Eyes/eyelashes.png", Thumbnail: "020-Eyes/eyelashes_thumbnail.png"},
+				{ID: "eyes", Name: "Eyes", Image: "020-Eyes/eyes.png", Thumbnail: "020-Eyes/eyes_thumbnail.png"},
+				{ID: "eyes_angry", Name: "Eyes Angry", Image: "020-Eyes/eyes_angry.png", Thumbnail: "020-Eyes/eyes_angry_thumbnail.png"},
+				{ID: "goofy_eyes", Name: "Goofy Eyes", Image: "020-Eyes/goofy_eyes.png", Thumbnail: "020-Eyes/goofy_eyes_thumbnail.png"},
+				{ID: "looking_left", Name: "Looking Left", Image: "020-Eyes/looking_left.png", Thumbnail: "020-Eyes/looking_left_thumbnail.png"},
+				{ID: "looking_right", Name: "Looking Right", Image: "020-Eyes/looking_right.png", Thumbnail: "020-Eyes/looking_right_thumbnail.png"},
+				{ID: "looking_up_lashes", Name: "Looking Up Lashes", Image: "020-Eyes/looking_up_lashes.png", Thumbnail: "020-Eyes/looking_up_lashes_thumbnail.png"},
+				{ID: "looking_up_no_lashes", Name: "Looking Up No Lashes", Image: "020-Eyes/looking_up_no_lashes.png", Thumbnail: "020-Eyes/looking_up_no_lashes_thumbnail.png"},
+			},
+		},
+		{
+			ID:    "shirts",
+			Name:  "Shirts",
+			Dir:   "021-Shirts",
+			Order: 21,
+			Options: []*gopher.Option{
+				{ID: "1_up_shirt", Name: "1 Up Shirt", Image: "021-Shirts/1_up_shirt.png", Thumbnail: "021-Shirts/1_up_shirt_thumbnail.png"},
+				{ID: "black_heart_shirt", Name: "Black Heart Shirt", Image: "021-Shirts/black_heart_shirt.png", Thumbnail: "021-Shirts/black_heart_shirt_thumbnail.png"},
+				{ID: "black_shirt", Name: "Black Shirt", Image: "021-Shirts/black_shirt.png", Thumbnail: "021-Shirts/black_shirt_thumbnail.png"},
+				{ID: "docker_shirt", Name: "Docker Shirt", Image: "021-Shirts/docker_shirt.png", Thumbnail: "021-Shirts/docker_shirt_thumbnail.png"},
+				{ID: "emc_code", Name: "Emc Code", Image: "021-Shirts/emc_code.png", Thumbnail: "021-Shirts/emc_code_thumbnail.png"},
+				{ID: "emc_code_shirt", Name: "Emc Code Shirt", Image: "021-Shirts/emc_code_shirt.png", Thumbnail: "021-Shirts/emc_code_shirt_thumbnail.png"},
+				{ID: "freebsd_beastie", Name: "Freebsd Beastie", Image: "021-Shirts/freebsd_beastie.png", Thumbnail: "021-Shirts/freebsd_beastie_thumbnail.png"},
+				{ID: "freebsd_shirt", Name: "Freebsd Shirt", Image: "021-Shirts/freebsd_shirt.png", Thumbnail: "021-Shirts/freebsd_shirt_thumbnail.png"},
+				{ID: "game_over_shirt", Name: "Game Over Shirt", Image: "021-Shirts/game_over_shirt.png", Thumbnail: "021-Shirts/game_over_shirt_thumbnail.png"},
+				{ID: "gay_pride_shirt", Name: "Gay Pride Shirt", Image: "021-Shirts/gay_pride_shirt.png", Thumbnail: "021-Shirts/gay_pride_shirt_thumbnail.png"},
+				{ID: "girls_who_code_shirt", Name: "Girls Who Code Shirt", Image: "021-Shirts/girls_who_code_shirt.png", Thumbnail: "021-Shirts/girls_who_code_shirt_thumbnail.png"},
+				{ID: "github", Name: "Github", Image: "021-Shirts/github.png", Thumbnail: "021-Shirts/github_thumbnail.png"},
+				{ID: "go_academy_shirt", Name: "Go Academy Shirt", Image: "021-Shirts/go_academy_shirt.png", Thumbnail: "021-Shirts/go_academy_shirt_thumbnail.png"},
+				{ID: "gobuffalo_shirt", Name: "Gobuffalo Shirt", Image: "021-Shirts/gobuffalo_shirt.png", Thumbnail: "021-Shirts/gobuffalo_shirt_thumbnail.png"},
+				{ID: "golang_news", Name: "Golang News", Image: "021-Shirts/golang_news.png", Thumbnail: "021-Shirts/golang_news_thumbnail.png"},
+				{ID: "golang_shirt", Name: "Golang Shirt", Image: "021-Shirts/golang_shirt.png", Thumbnail: "021-Shirts/golang_shirt_thumbnail.png"},
+				{ID: "google_shirt", Name: "Google Shirt", Image: "021-Shirts/google_shirt.png", Thumbnail: "021-Shirts/google_shirt_thumbnail.png"},
+				{ID: "gopher_BBQ", Name: "Gopher BBQ", Image: "021-Shirts/gopher_BBQ.png", Thumbnail: "021-Shirts/gopher_BBQ_thumbnail.png"},
+				{ID: "gopher_starwars_shirt", Name: "Gopher Starwars Shirt", Image: "021-Shirts/gopher_starwars_shirt.png", Thumbnail: "021-Shirts/gopher_starwars_shirt_thumbnail.png"},
+				{ID: "gophercon_shirt", Name: "Gophercon Shirt", Image: "021-Shirts/gophercon_shirt.png", Thumbnail: "021-Shirts/gophercon_shirt_thumbnail.png"},
+				{ID: "gotham_go_shirt", Name: "Gotham Go Shirt", Image: "021-Shirts/gotham_go_shirt.png", Thumbnail: "021-Shirts/gotham_go_shirt_thumbnail.png"},
+				{ID: "gotime", Name: "Gotime", Image: "021-Shirts/gotime.png", Thumbnail: "021-Shirts/gotime_thumbnail.png"},
+				{ID: "grey_shirt", Name: "Grey Shirt", Image: "021-Shirts/grey_shirt.png", Thumbnail: "021-Shirts/grey_shirt_thumbnail.png"},
+				{ID: "groove_shirt", Name: "Groove Shirt", Image: "021-Shirts/groove_shirt.png", Thumbnail: "021-Shirts/groove_shirt_thumbnail.png"},
+				{ID: "hawaiian_shirt", Name: "Hawaiian Shirt", Image: "021-Shirts/hawaiian_shirt.png", Thumbnail: "021-Shirts/hawaiian_shirt_thumbnail.png"},
+				{ID: "hawaiian_shirt_solid", Name: "Hawaiian Shirt Solid", Image: "021-Shirts/hawaiian_shirt_solid.png", Thumbnail: "021-Shirts/hawaiian_shirt_solid_thumbnail.png"},
+				{ID: "heman_shirt", Name: "Heman Shirt", Image: "021-Shirts/heman_shirt.png", Thumbnail: "021-Shirts/heman_shirt_thumbnail.png"},
+				{ID: "influx_db", Name: "Influx Db", Image: "021-Shirts/influx_db.png", Thumbnail: "021-Shirts/influx_db_thumbnail.png"},
+				{ID: "kubernetes_shirt", Name: "Kubernetes Shirt", Image: "021-Shirts/kubernetes_shirt.png", Thumbnail: "021-Shirts/kubernetes_shirt_thumbnail.png"},
+				{ID: "linux_shirt", Name: "Linux Shirt", Image: "021-Shirts/linux_shirt.png", Thumbnail: "021-Shirts/linux_shirt_thumbnail.png"},
+				{ID: "my_little_pony_shirt", Name: "My Little Pony Shirt", Image: "021-Shirts/my_little_pony_shirt.png", Thumbnail: "021-Shirts/my_little_pony_shirt_thumbnail.png"},
+				{ID: "new_relic_nerd_life", Name: "New Relic Nerd Life", Image: "021-Shirts/new_relic_nerd_life.png", Thumbnail: "021-Shirts/new_relic_nerd_life_thumbnail.png"},
+				{ID: "objectrocket_shirt", Name: "Objectrocket Shirt", Image: "021-Shirts/objectrocket_shirt.png", Thumbnail: "021-Shirts/objectrocket_shirt_thumbnail.png"},
+				{ID: "Octocat", Name: "Octocat", Image: "021-Shirts/Octocat.png", Thumbnail: "021-Shirts/Octocat_thumbnail.png"},
+				{ID: "Octocat_1", Name: "Octocat 1", Image: "021-Shirts/Octocat_1.png", Thumbnail: "021-Shirts/Octocat_1_thumbnail.png"},
+				{ID: "pacman_shirt", Name: "Pacman Shirt", Image: "021-Shirts/pacman_shirt.png", Thumbnail: "021-Shirts/pacman_shirt_thumbnail.png"},
+				{ID: "pacman_shirt_1", Name: "Pacman Shirt 1", Image: "021-Shirts/pacman_shirt_1.png", Thumbnail: "021-Shirts/pacman_shirt_1_thumbnail.png"},
+				{ID: "php_shirt", Name: "Php Shirt", Image: "021-Shirts/php_shirt.png", Thumbnail: "021-Shirts/php_shirt_thumbnail.png"},
+				{ID: "pink_rainbow_shirt", Name: "Pink Rainbow Shirt", Image: "021-Shirts/pink_rainbow_shirt.png", Thumbnail: "021-Shirts/pink_rainbow_shirt_thumbnail.png"},
+				{ID: "pink_shirt", Name: "Pink Shirt", Image: "021-Shirts/pink_shirt.png", Thumbnail: "021-Shirts/pink_shirt_thumbnail.png"},
+				{ID: "Pivotal", Name: "Pivotal", Image: "021-Shirts/Pivotal.png", Thumbnail: "021-Shirts/Pivotal_thumbnail.png"},
+				{ID: "rainbow_brite", Name: "Rainbow Brite", Image: "021-Shirts/rainbow_brite.png", Thumbnail: "021-Shirts/rainbow_brite_thumbnail.png"},
+				{ID: "shera_shirt", Name: "Shera Shirt", Image: "021-Shirts/shera_shirt.png", Thumbnail: "021-Shirts/shera_shirt_thumbnail.png"},
+				{ID: "skull_and_crossbones", Name: "Skull And Crossbones", Image: "021-Shirts/skull_and_crossbones.png", Thumbnail: "021-Shirts/skull_and_crossbones_thumbnail.png"},
+				{ID: "star_shirt", Name: "Star Shirt", Image: "021-Shirts/star_shirt.png", Thumbnail: "021-Shirts/star_shirt_thumbnail.png"},
+				{ID: "tetris", Name: "Tetris", Image: "021-Shirts/tetris.png", Thumbnail: "021-Shirts/tetris_thumbnail.png"},
+				{ID: "the_channellog", Name: "The Channellog", Image: "021-Shirts/the_channellog.png", Thumbnail: "021-Shirts/the_channellog_thumbnail.png"},
+				{ID: "tuxedo", Name: "Tuxedo", Image: "021-Shirts/tuxedo.png", Thumbnail: "021-Shirts/tuxedo_thumbnail.png"},
+				{ID: "ubuntu", Name: "Ubuntu", Image: "021-Shirts/ubuntu.png", Thumbnail: "021-Shirts/ubuntu_thumbnail.png"},
+				{ID: "women_who_go", Name: "Women Who Go", Image: "021-Shirts/women_who_go.png", Thumbnail: "021-Shirts/women_who_go_thumbnail.png"},
+				{ID: "women_who_go_berlin", Name: "Women Who Go Berlin", Image: "021-Shirts/women_who_go_berlin.png", Thumbnail: "021-Shirts/women_who_go_berlin_thumbnail.png"},
+				{ID: "zelda", Name: "Zelda", Image: "021-Shirts/zelda.png", Thumbnail: "021-Shirts/zelda_thumbnail.png"},
+			},
+		},
+		{
+			ID:    "hair",
+			Name:  "Hair",
+			Dir:   "022-Hair",
+			Order: 22,
+			Options: []*gopher.Option{
+				{ID: "ash_blonde_hair", Name: "Ash Blonde Hair", Image: "022-Hair/ash_blonde_hair.png", Thumbnail: "022-Hair/ash_blonde_hair_thumbnail.png"},
+				{ID: "black_hair", Name: "Black Hair", Image: "022-Hair/black_hair.png", Thumbnail: "022-Hair/black_hair_thumbnail.png"},
+				{ID: "blonde_bangs", Name: "Blonde Bangs", Image: "022-Hair/blonde_bangs.png", Thumbnail: "022-Hair/blonde_bangs_thumbnail.png"},
+				{ID: "blonde_hair_blue_ears", Name: "Blonde Hair Blue Ears", Image: "022-Hair/blonde_hair_blue_ears.png", Thumbnail: "022-Hair/blonde_hair_blue_ears_thumbnail.png", Colour: "c0e4e3"},
+				{ID: "blonde_hair_pink_ears", Name: "Blonde Hair Pink Ears", Image: "022-Hair/blonde_hair_pink_ears.png", Thumbnail: "022-Hair/blonde_hair_pink_ears_thumbnail.png", Colour: "ffcaca"},
+				{ID: "blonde_swoop_hair", Name: "Blonde Swoop Hair", Image: "022-Hair/blonde_swoop_hair.png", Thumbnail: "022-Hair/blonde_swoop_hair_thumbnail.png"},
+				{ID: "blue_ear_afro", Name: "Blue Ear Afro", Image: "022-Hair/blue_ear_afro.png", Thumbnail: "022-Hair/blue_ear_afro_thumbnail.png", Colour: "c0e4e3"},
+				{ID: "blue_ear_curly_hair", Name: "Blue Ear Curly Hair", Image: "022-Hair/blue_ear_curly_hair.png", Thumbnail: "022-Hair/blue_ear_curly_hair_thumbnail.png", Colour: "c0e4e3"},
+				{ID: "brian_ketelsen_hair", Name: "Brian Ketelsen Hair", Image: "022-Hair/brian_ketelsen_hair.png", Thumbnail: "022-Hair/brian_ketelsen_hair_thumbnail.png", Tags: []string{"brian ketelsen"}, Artist: "Ashley McNamara", Licence: "CC-BY-NC-SA-4.0"},
+				{ID: "brown_hair_bangs", Name: "Brown Hair Bangs", Image: "022-Hair/brown_hair_bangs.png", Thumbnail: "022-Hair/brown_hair_bangs_thumbnail.png"},
+				{ID: "brown_hair_blue_ears", Name: "Brown Hair Blue Ears", Image: "022-Hair/brown_hair_blue_ears.png", Thumbnail: "022-Hair/brown_hair_blue_ears_thumbnail.png", Colour: "c0e4e3"},
+				{ID: "brown_hair_ears_blue", Name: "Brown Hair Ears Blue", Image: "022-Hair/brown_hair_ears_blue.png", Thumbnail: "022-Hair/brown_hair_ears_blue_thumbnail.png", Colour: "c0e4e3"},
+				{ID: "brown_hair_long", Name: "Brown Hair Long", Image: "022-Hair/brown_hair_long.png", Thumbnail: "022-Hair/brown_hair_long_thumbnail.png"},
+				{ID: "brown_hair_pink_ears", Name: "Brown Hair Pink Ears", Image: "022-Hair/brown_hair_pink_ears.png", Thumbnail: "022-Hair/brown_hair_pink_ears_thumbnail.png", Colour: "ffcaca"},
+				{ID: "brown_hawk", Name: "Brown Hawk", Image: "022-Hair/brown_hawk.png", Thumbnail: "022-Hair/brown_hawk_thumbnail.png"},
+				{ID: "brown_mohawk", Name: "Brown Mohawk", Image: "022-Hair/brown_mohawk.png", Thumbnail: "022-Hair/brown_mohawk_thumbnail.png"},
+				{ID: "brown_swoop_hair", Name: "Brown Swoop Hair", Image: "022-Hair/brown_swoop_hair.png", Thumbnail: "022-Hair/brown_swoop_hair_thumbnail.png"},
+				{ID: "center_brown_hair", Name: "Center Brown Hair", Image: "022-Hair/center_brown_hair.png", Thumbnail: "022-Hair/center_brown_hair_thumbnail.png"},
+				{ID: "combed_front_brown_hair", Name: "Combed Front Brown Hair", Image: "022-Hair/combed_front_brown_hair.png", Thumbnail: "022-Hair/combed_front_brown_hair_thumbnail.png"},
+				{ID: "combed_front_grey_hair", Name: "Combed Front Grey Hair", Image: "022-Hair/combed_front_grey_hair.png", Thumbnail: "022-Hair/combed_front_grey_hair_thumbnail.png"},
+				{ID: "combed_left_red_hair", Name: "Combed Left Red Hair", Image: "022-Hair/combed_left_red_hair.png", Thumbnail: "022-Hair/combed_left_red_hair_thumbnail.png"},
+				{ID: "combed_side_hair", Name: "Combed Side Hair", Image: "022-Hair/combed_side_hair.png", Thumbnail: "022-Hair/combed_side_hair_thumbnail.png"},
+				{ID: "curly_blonde", Name: "Curly Blonde", Image: "022-Hair/curly_blonde.png", Thumbnail: "022-Hair/curly_blonde_thumbnail.png"},
+				{ID: "curly_red", Name: "Curly Red", Image: "022-Hair/curly_red.png", Thumbnail: "022-Hair/curly_red_thumbnail.png"},
+				{ID: "guy_short_black_hair", Name: "Guy Short Black Hair", Image: "022-Hair/guy_short_black_hair.png", Thumbnail: "022-Hair/guy_short_black_hair_thumbnail.png"},
+				{ID: "hair_black", Name: "Hair Black", Image: "022-Hair/hair_black.png", Thumbnail: "022-Hair/hair_black_thumbnail.png"},
+				{ID: "hair_blonde", Name: "Hair Blonde", Image: "022-Hair/hair_blonde.png", Thumbnail: "022-Hair/hair_blonde_thumbnail.png"},
+				{ID: "hair_brown", Name: "Hair Brown", Image: "022-Hair/hair_brown.png", Thumbnail: "022-Hair/hair_brown_thumbnail.png"},
+				{ID: "hair_red", Name: "Hair Red", Image: "022-Hair/hair_red.png", Thumbnail: "022-Hair/hair_red_thumbnail.png"},
+				{ID: "hipster_hair", Name: "Hipster Hair", Image: "022-Hair/hipster_hair.png", Thumbnail: "022-Hair/hipster_hair_thumbnail.png"},
+				{ID: "hipster_pack", Name: "Hipster Pack", Image: "022-Hair/hipster_pack.png", Thumbnail: "022-Hair/hipster_pack_thumbnail.png"},
+				{ID: "lavender_bangs", Name: "Lavender Bangs", Image: "022-Hair/lavender_bangs.png", Thumbnail: "022-Hair/lavender_bangs_thumbnail.png"},
+				{ID: "long_blonde_hair", Name: "Long Blonde Hair", Image: "022-Hair/long_blonde_hair.png", Thumbnail: "022-Hair/long_blonde_hair_thumbnail.png"},
+				{ID: "long_dark_brown_hair", Name: "Long Dark Brown Hair", Image: "022-Hair/long_dark_brown_hair.png", Thumbnail: "022-Hair/long_dark_brown_hair_thumbnail.png"},
+				{ID: "man_bun", Name: "Man Bun", Image: "022-Hair/man_bun.png", Thumbnail: "022-Hair/man_bun_thumbnail.png"},
+				{ID: "pink_bangs", Name: "Pink Bangs", Image: "022-Hair/pink_bangs.png", Thumbnail: "022-Hair/pink_bangs_thumbnail.png"},
+				{ID: "pink_ear_afro", Name: "Pink Ear Afro", Image: "022-Hair/pink_ear_afro.png", Thumbnail: "022-Hair/pink_ear_afro_thumbnail.png", Colour: "ffcaca"},
+				{ID: "pink_ear_curly_hair", Name: "Pink Ear Curly Hair", Image: "022-Hair/pink_ear_curly_hair.png", Thumbnail: "022-Hair/pink_ear_curly_hair_thumbnail.png", Colour: "ffcaca"},
+				{ID: "pink_hair_blue_ears", Name: "Pink Hair Blue Ears", Image: "022-Hair/pink_hair_blue_ears.png", Thumbnail: "022-Hair/pink_hair_blue_ears_thumbnail.png", Colour: "c0e4e3"},
+				{ID: "pink_hair_pink_ears", Name: "Pink Hair Pink Ears", Image: "022-Hair/pink_hair_pink_ears.png", Thumbnail: "022-Hair/pink_hair_pink_ears_thumbnail.png", Colour: "ffcaca"},
+				{ID: "pink_unicorn", Name: "Pink Unicorn", Image: "022-Hair/pink_unicorn.png", Thumbnail: "022-Hair/pink_unicorn_thumbnail.png"},
+				{ID: "rainbow_hair", Name: "Rainbow Hair", Image: "022-Hair/rainbow_hair.png", Thumbnail: "022-Hair/rainbow_hair_thumbnail.png"},
+				{ID: "rainbow_unicorn", Name: "Rainbow Unicorn", Image: "022-Hair/rainbow_unicorn.png", Thumbnail: "022-Hair/rainbow_unicorn_thumbnail.png"},
+				{ID: "rakyll_hair", Name: "Rakyll Hair", Image: "022-Hair/rakyll_hair.png", Thumbnail: "022-Hair/rakyll_hair_thumbnail.png"},
+				{ID: "red_bangs", Name: "Red Bangs", Image: "022-Hair/red_bangs.png", Thumbnail: "022-Hair/red_bangs_thumbnail.png"},
+				{ID: "red_hair_blue_ears", Name: "Red Hair Blue Ears", Image: "022-Hair/red_hair_blue_ears.png", Thumbnail: "022-Hair/red_hair_blue_ears_thumbnail.png", Colour: "c0e4e3"},
+				{ID: "red_hair_pink_ears", Name: "Red Hair Pink Ears", Image: "022-Hair/red_hair_pink_ears.png", Thumbnail: "022-Hair/red_hair_pink_ears_thumbnail.png", Colour: "ffcaca"},
+				{ID: "red_hipster_hair", Name: "Red Hipster Hair", Image: "022-Hair/red_hipster_hair.png", Thumbnail: "022-Hair/red_hipster_hair_thumbnail.png"},
+				{ID: "red_mohawk", Name: "Red Mohawk", Image: "022-Hair/red_mohawk.png", Thumbnail: "022-Hair/red_mohawk_thumbnail.png"},
+				{ID: "red_swoop_hair", Name: "Red Swoop Hair", Image: "022-Hair/red_swoop_hair.png", Thumbnail: "022-Hair/red_swoop_hair_thumbnail.png"},
+				{ID: "side_hair", Name: "Side Hair", Image: "022-Hair/side_hair.png", Thumbnail: "022-Hair/side_hair_thumbnail.png"},
+				{ID: "the_dave_cheney_beard", Name: "The Dave Cheney Beard", Image: "022-Hair/the_dave_cheney_beard.png", Thumbnail: "022-Hair/the_dave_cheney_beard_thumbnail.png"},
+				{ID: "trump_hair", Name: "Trump Hair", Image: "022-Hair/trump_hair.png", Thumbnail: "022-Hair/trump_hair_thumbnail.png"},
+			},
+		},
+		{
+			ID:    "facial_hair",
+			Name:  "Facial Hair",
+			Dir:   "023-Facial_Hair",
+			Order: 23,
+			Options: []*gopher.Option{
+				{ID: "black_beard", Name: "Black Beard", Image: "023-Facial_Hair/black_beard.png", Thumbnail: "023-Facial_Hair/black_beard_thumbnail.png"},
+				{ID: "black_moustache", Name: "Black Moustache", Image: "023-Facial_Hair/black_moustache.png", Thumbnail: "023-Facial_Hair/black_moustache_thumbnail.png"},
+				{ID: "black_stache", Name: "Black Stache", Image: "023-Facial_Hair/black_stache.png", Thumbnail: "023-Facial_Hair/black_stache_thumbnail.png"},
+				{ID: "blonde_beard", Name: "Blonde Beard", Image: "023-Facial_Hair/blonde_beard.png", Thumbnail: "023-Facial_Hair/blonde_beard_thumbnail.png"},
+				{ID: "blonde_moustache", Name: "Blonde Moustache", Image: "023-Facial_Hair/blonde_moustache.png", Thumbnail: "023-Facial_Hair/blonde_moustache_thumbnail.png"},
+				{ID: "blonde_stache", Name: "Blonde Stache", Image: "023-Facial_Hair/blonde_stache.png", Thumbnail: "023-Facial_Hair/blonde_stache_thumbnail.png"},
+				{ID: "brown_beard", Name: "Brown Beard", Image: "023-Facial_Hair/brown_beard.png", Thumbnail: "023-Facial_Hair/brown_beard_thumbnail.png"},
+				{ID: "brown_beard_1", Name: "Brown Beard 1", Image: "023-Facial_Hair/brown_beard_1.png", Thumbnail: "023-Facial_Hair/brown_beard_1_thumbnail.png"},
+				{ID: "brown_beard_medium", Name: "Brown Beard Medium", Image: "023-Facial_Hair/brown_beard_medium.png", Thumbnail: "023-Facial_Hair/brown_beard_medium_thumbnail.png"},
+				{ID: "brown_moustache", Name: "Brown Moustache", Image: "023-Facial_Hair/brown_moustache.png", Thumbnail: "023-Facial_Hair/brown_moustache_thumbnail.png"},
+				{ID: "brown_pirate_beard", Name: "Brown Pirate Beard", Image: "023-Facial_Hair/brown_pirate_beard.png", Thumbnail: "023-Facial_Hair/brown_pirate_beard_thumbnail.png"},
+				{ID: "brown_stache", Name: "Brown Stache", Image: "023-Facial_Hair/brown_stache.png", Thumbnail: "023-Facial_Hair/brown_stache_thumbnail.png"},
+				{ID: "detailed_blonde_beard", Name: "Detailed Blonde Beard", Image: "023-Facial_Hair/detailed_blonde_beard.png", Thumbnail: "023-Facial_Hair/detailed_blonde_beard_thumbnail.png"},
+				{ID: "extra_long_brown_beard", Name: "Extra Long Brown Beard", Image: "023-Facial_Hair/extra_long_brown_beard.png", Thumbnail: "023-Facial_Hair/extra_long_brown_beard_thumbnail.png"},
+				{ID: "full_ash_blonde_beard", Name: "Full Ash Blonde Beard", Image: "023-Facial_Hair/full_ash_blonde_beard.png", Thumbnail: "023-Facial_Hair/full_ash_blonde_beard_thumbnail.png"},
+				{ID: "full_blonde_beard", Name: "Full Blonde Beard", Image: "023-Facial_Hair/full_blonde_beard.png", Thumbnail: "023-Facial_Hair/full_blonde_beard_thumbnail.png"},
+				{ID: "full_red_beard", Name: "Full Red Beard", Image: "023-Facial_Hair/full_red_beard.png", Thumbnail: "023-Facial_Hair/full_red_beard_thumbnail.png"},
+				{ID: "full_redish_beard", Name: "Full Redish Beard", Image: "023-Facial_Hair/full_redish_beard.png", Thumbnail: "023-Facial_Hair/full_redish_beard_thumbnail.png"},
+				{ID: "grey_stache", Name: "Grey Stache", Image: "023-Facial_Hair/grey_stache.png", Thumbnail: "023-Facial_Hair/grey_stache_thumbnail.png"},
+				{ID: "mat_ryer_pirate_beard", Name: "Mat Ryer Pirate Beard", Image: "023-Facial_Hair/mat_ryer_pirate_beard.png", Thumbnail: "023-Facial_Hair/mat_ryer_pirate_beard_thumbnail.png", Tags: []string{"mat ryer", "pirate"}, Artist: "Ashley McNamara", Licence: "CC-BY-NC-SA-4.0"},
+				{ID: "moustache_red", Name: "Moustache Red", Image: "023-Facial_Hair/moustache_red.png", Thumbnail: "023-Facial_Hair/moustache_red_thumbnail.png"},
+				{ID: "multi_colored_beard", Name: "Multi Colored Beard", Image: "023-Facial_Hair/multi_colored_beard.png", Thumbnail: "023-Facial_Hair/multi_colored_beard_thumbnail.png"},
+				{ID: "red_beard", Name: "Red Beard", Image: "023-Facial_Hair/red_beard.png", Thumbnail: "023-Facial_Hair/red_beard_thumbnail.png"},
+				{ID: "red_soul_patch", Name: "Red Soul Patch", Image: "023-Facial_Hair/red_soul_patch.png", Thumbnail: "023-Facial_Hair/red_soul_patch_thumbnail.png"},
+				{ID: "short_black_beard", Name: "Short Black Beard", Image: "023-Facial_Hair/short_black_beard.png", Thumbnail: "023-Facial_Hair/short_black_beard_thumbnail.png"},
+				{ID: "short_black_beard1", Name: "Short Black Beard1", Image: "023-Facial_Hair/short_black_beard1.png", Thumbnail: "023-Facial_Hair/short_black_beard1_thumbnail.png"},
+				{ID: "short_blonde_beard", Name: "Short Blonde Beard", Image: "023-Facial_Hair/short_blonde_beard.png", Thumbnail: "023-Facial_Hair/short_blonde_beard_thumbnail.png"},
+				{ID: "short_copper_beard", Name: "Short Copper Beard", Image: "023-Facial_Hair/short_copper_beard.png", Thumbnail: "023-Facial_Hair/short_copper_beard_thumbnail.png"},
+				{ID: "short_full_black_beard", Name: "Short Full Black Beard", Image: "023-Facial_Hair/short_full_black_beard.png", Thumbnail: "023-Facial_Hair/short_full_black_beard_thumbnail.png"},
+				{ID: "short_full_blonde_beard", Name: "Short Full Blonde Beard", Image: "023-Facial_Hair/short_full_blonde_beard.png", Thumbnail: "023-Facial_Hair/short_full_blonde_beard_thumbnail.png"},
+				{ID: "short_full_grey_beard", Name: "Short Full Grey Beard", Image: "023-Facial_Hair/short_full_grey_beard.png", Thumbnail: "023-Facial_Hair/short_full_grey_beard_thumbnail.png"},
+				{ID: "short_full_red_beard", Name: "Short Full Red Beard", Image: "023-Facial_Hair/short_full_red_beard.png", Thumbnail: "023-Facial_Hair/short_full_red_beard_thumbnail.png"},
+				{ID: "small_brown_stache", Name: "Small Brown Stache", Image: "023-Facial_Hair/small_brown_stache.png", Thumbnail: "023-Facial_Hair/small_brown_stache_thumbnail.png"},
+				{ID: "straight_stache", Name: "Straight Stache", Image: "023-Facial_Hair/straight_stache.png", Thumbnail: "023-Facial_Hair/straight_stache_thumbnail.png"},
+				{ID: "stubble", Name: "Stubble", Image: "023-Facial_Hair/stubble.png", Thumbnail: "023-Facial_Hair/stubble_thumbnail.png"},
+				{ID: "this_weird_thing", Name: "This Weird Thing", Image: "023-Facial_Hair/this_weird_thing.png", Thumbnail: "023-Facial_Hair/this_weird_thing_thumbnail.png"},
+			},
+		},
+		{
+			ID:    "glasses",
+			Name:  "Glasses",
+			Dir:   "024-Glasses",
+			Order: 24,
+			Options: []*gopher.Option{
+				{ID: "all_black_sunglasses", Name: "All Black Sunglasses", Image: "024-Glasses/all_black_sunglasses.png", Thumbnail: "024-Glasses/all_black_sunglasses_thumbnail.png"},
+				{ID: "black_rimmed_glasses", Name: "Black Rimmed Glasses", Image: "024-Glasses/black_rimmed_glasses.png", Thumbnail: "024-Glasses/black_rimmed_glasses_thumbnail.png"},
+				{ID: "blue_lenses", Name: "Blue Lenses", Image: "024-Glasses/blue_lenses.png", Thumbnail: "024-Glasses/blue_lenses_thumbnail.png"},
+				{ID: "blue_sunglasses", Name: "Blue Sunglasses", Image: "024-Glasses/blue_sunglasses.png", Thumbnail: "024-Glasses/blue_sunglasses_thumbnail.png"},
+				{ID: "funky_glasses", Name: "Funky Glasses", Image: "024-Glasses/funky_glasses.png", Thumbnail: "024-Glasses/funky_glasses_thumbnail.png"},
+				{ID: "funky_green_glasses", Name: "Funky Green Glasses", Image: "024-Glasses/funky_green_glasses.png", Thumbnail: "024-Glasses/funky_green_glasses_thumbnail.png"},
+				{ID: "green_lenses", Name: "Green Lenses", Image: "024-Glasses/green_lenses.png", Thumbnail: "024-Glasses/green_lenses_thumbnail.png"},
+				{ID: "heart_glasses", Name: "Heart Glasses", Image: "024-Glasses/heart_glasses.png", Thumbnail: "024-Glasses/heart_glasses_thumbnail.png"},
+				{ID: "hipster_glasses1", Name: "Hipster Glasses1", Image: "024-Glasses/hipster_glasses1.png", Thumbnail: "024-Glasses/hipster_glasses1_thumbnail.png"},
+				{ID: "movie_glasses", Name: "Movie Glasses", Image: "024-Glasses/movie_glasses.png", Thumbnail: "024-Glasses/movie_glasses_thumbnail.png"},
+				{ID: "nerd_glasses", Name: "Nerd Glasses", Image: "024-Glasses/nerd_glasses.png", Thumbnail: "024-Glasses/nerd_glasses_thumbnail.png"},
+				{ID: "pink_lenses", Name: "Pink Lenses", Image: "024-Glasses/pink_lenses.png", Thumbnail: "024-Glasses/pink_lenses_thumbnail.png"},
+				{ID: "red_glasses", Name: "Red Glasses", Image: "024-Glasses/red_glasses.png", Thumbnail: "024-Glasses/red_glasses_thumbnail.png"},
+				{ID: "red_sunglasses", Name: "Red Sunglasses", Image: "024-Glasses/red_sunglasses.png", Thumbnail: "024-Glasses/red_sunglasses_thumbnail.png"},
+				{ID: "round_black_rimmed_glasses", Name: "Round Black Rimmed Glasses", Image: "024-Glasses/round_black_rimmed_glasses.png", Thumbnail: "024-Glasses/round_black_rimmed_glasses_thumbnail.png"},
+				{ID: "round_glasses", Name: "Round Glasses", Image: "024-Glasses/round_glasses.png", Thumbnail: "024-Glasses/round_glasses_thumbnail.png"},
+				{ID: "round_red_sunglasses", Name: "Round Red Sunglasses", Image: "024-Glasses/round_red_sunglasses.png", Thumbnail: "024-Glasses/round_red_sunglasses_thumbnail.png"},
+				{ID: "small_black_sunglasses", Name: "Small Black Sunglasses", Image: "024-Glasses/small_black_sunglasses.png", Thumbnail: "024-Glasses/small_black_sunglasses_thumbnail.png"},
+				{ID: "square_glasses", Name: "Square Glasses", Image: "024-Glasses/square_glasses.png", Thumbnail: "024-Glasses/square_glasses_thumbnail.png"},
+				{ID: "square_glasses1", Name: "Square Glasses1", Image: "024-Glasses/square_glasses1.png", Thumbnail: "024-Glasses/square_glasses1_thumbnail.png"},
+				{ID: "sunglasses", Name: "Sunglasses", Image: "024-Glasses/sunglasses.png", Thumbnail: "024-Glasses/sunglasses_thumbnail.png"},
+			},
+		},
+		{
+			ID:    "hats_and_hair_accessories",
+			Name:  "Hats and Hair Accessories",
+			Dir:   "025-Hats_and_Hair_Accessories",
+			Order: 25,
+			Options: []*gopher.Option{
+				{ID: "bandana", Name: "Bandana", Image: "025-Hats_and_Hair_Accessories/bandana.png", Thumbnail: "025-Hats_and_Hair_Accessories/bandana_thumbnail.png"},
+				{ID: "bat_gopher", Name: "Bat Gopher", Image: "025-Hats_and_Hair_Accessories/bat_gopher.png", Thumbnail: "025-Hats_and_Hair_Accessories/bat_gopher_thumbnail.png"},
+				{ID: "beanie", Name: "Beanie", Image: "025-Hats_and_Hair_Accessories/beanie.png", Thumbnail: "025-Hats_and_Hair_Accessories/beanie_thumbnail.png"},
+				{ID: "birthday_hat", Name: "Birthday Hat", Image: "025-Hats_and_Hair_Accessories/birthday_hat.png", Thumbnail: "025-Hats_and_Hair_Accessories/birthday_hat_thumbnail.png"},
+				{ID: "bunny_ears", Name: "Bunny Ears", Image: "025-Hats_and_Hair_Accessories/bunny_ears.png", Thumbnail: "025-Hats_and_Hair_Accessories/bunny_ears_thumbnail.png"},
+				{ID: "cat_ears", Name: "Cat Ears", Image: "025-Hats_and_Hair_Accessories/cat_ears.png", Thumbnail: "025-Hats_and_Hair_Accessories/cat_ears_thumbnail.png"},
+				{ID: "flower_headband", Name: "Flower Headband", Image: "025-Hats_and_Hair_Accessories/flower_headband.png", Thumbnail: "025-Hats_and_Hair_Accessories/flower_headband_thumbnail.png"},
+				{ID: "gobuffalo_costume", Name: "Gobuffalo Costume", Image: "025-Hats_and_Hair_Accessories/gobuffalo_costume.png", Thumbnail: "025-Hats_and_Hair_Accessories/gobuffalo_costume_thumbnail.png"},
+				{ID: "graduation", Name: "Graduation", Image: "025-Hats_and_Hair_Accessories/graduation.png", Thumbnail: "025-Hats_and_Hair_Accessories/graduation_thumbnail.png"},
+				{ID: "headband", Name: "Headband", Image: "025-Hats_and_Hair_Accessories/headband.png", Thumbnail: "025-Hats_and_Hair_Accessories/headband_thumbnail.png"},
+				{ID: "king_queen", Name: "King Queen", Image: "025-Hats_and_Hair_Accessories/king_queen.png", Thumbnail: "025-Hats_and_Hair_Accessories/king_queen_thumbnail.png"},
+				{ID: "Large_black_yellow_bow", Name: "Large Black Yellow Bow", Image: "025-Hats_and_Hair_Accessories/Large_black_yellow_bow.png", Thumbnail: "025-Hats_and_Hair_Accessories/Large_black_yellow_bow_thumbnail.png"},
+				{ID: "moar_viking", Name: "Moar Viking", Image: "025-Hats_and_Hair_Accessories/moar_viking.png", Thumbnail: "025-Hats_and_Hair_Accessories/moar_viking_thumbnail.png"},
+				{ID: "pink_flower_headband", Name: "Pink Flower Headband", Image: "025-Hats_and_Hair_Accessories/pink_flower_headband.png", Thumbnail: "025-Hats_and_Hair_Accessories/pink_flower_headband_thumbnail.png"},
+				{ID: "pirate_hat", Name: "Pirate Hat", Image: "025-Hats_and_Hair_Accessories/pirate_hat.png", Thumbnail: "025-Hats_and_Hair_Accessories/pirate_hat_thumbnail.png"},
+				{ID: "ponzu_cms_costume", Name: "Ponzu CMS Costume", Image: "025-Hats_and_Hair_Accessories/ponzu_cms_costume.png", Thumbnail: "025-Hats_and_Hair_Accessories/ponzu_cms_costume_thumbnail.png", Tags: []string{"ponzu", "cms"}, Artist: "Ashley McNamara", Licence: "CC-BY-NC-SA-4.0", Sponsored: true},
+				{ID: "purple_bow", Name: "Purple Bow", Image: "025-Hats_and_Hair_Accessories/purple_bow.png", Thumbnail: "025-Hats_and_Hair_Accessories/purple_bow_thumbnail.png"},
+				{ID: "purple_flower", Name: "Purple Flower", Image: "025-Hats_and_Hair_Accessories/purple_flower.png", Thumbnail: "025-Hats_and_Hair_Accessories/purple_flower_thumbnail.png"},
+				{ID: "ship_captain", Name: "Ship Captain", Image: "025-Hats_and_Hair_Accessories/ship_captain.png", Thumbnail: "025-Hats_and_Hair_Accessories/ship_captain_thumbnail.png"},
+				{ID: "skull_bandana", Name: "Skull Bandana", Image: "025-Hats_and_Hair_Accessories/skull_bandana.png", Thumbnail: "025-Hats_and_Hair_Accessories/skull_bandana_thumbnail.png"},
+				{ID: "stay_puft", Name: "Stay Puft", Image: "025-Hats_and_Hair_Accessories/stay_puft.png", Thumbnail: "025-Hats_and_Hair_Accessories/stay_puft_thumbnail.png"},
+				{ID: "steampunk_tophat", Name: "Steampunk Tophat", Image: "025-Hats_and_Hair_Accessories/steampunk_tophat.png", Thumbnail: "025-Hats_and_Hair_Accessories/steampunk_tophat_thumbnail.png"},
+				{ID: "the_bill_kennedy", Name: "The Bill Kennedy", Image: "025-Hats_and_Hair_Accessories/the_bill_kennedy.png", Thumbnail: "025-Hats_and_Hair_Accessories/the_bill_kennedy_thumbnail.png"},
+				{ID: "unicorn_horn_pink", Name: "Unicorn Horn Pink", Image: "025-Hats_and_Hair_Accessories/unicorn_horn_pink.png", Thumbnail: "025-Hats_and_Hair_Accessories/unicorn_horn_pink_thumbnail.png"},
+				{ID: "viking_hat", Name: "Viking Hat", Image: "025-Hats_and_Hair_Accessories/viking_hat.png", Thumbnail: "025-Hats_and_Hair_Accessories/viking_hat_thumbnail.png"},
+				{ID: "wicked_tophat", Name: "Wicked Tophat", Image: "025-Hats_and_Hair_Accessories/wicked_tophat.png", Thumbnail: "025-Hats_and_Hair_Accessories/wicked_tophat_thumbnail.png"},
+				{ID: "yarmulke", Name: "Yarmulke", Image: "025-Hats_and_Hair_Accessories/yarmulke.png", Thumbnail: "025-Hats_and_Hair_Accessories/yarmulke_thumbnail.png"},
+				{ID: "yellow_bow", Name: "Yellow Bow", Image: "025-Hats_and_Hair_Accessories/yellow_bow.png", Thumbnail: "025-Hats_and_Hair_Accessories/yellow_bow_thumbnail.png"},
+			},
+		},
+		{
+			ID:    "extras",
+			Name:  "Extras",
+			Dir:   "027-Extras",
+			Order: 27,
+			Multi: true,
+			Options: []*gopher.Option{
+				{ID: "bowtie", Name: "Bowtie", Image: "027-Extras/bowtie.png", Thumbnail: "027-Extras/bowtie_thumbnail.png"},
+				{ID: "necklace", Name: "Necklace", Image: "027-Extras/necklace.png", Thumbnail: "027-Extras/necklace_thumbnail.png"},
+				{ID: "stripe_bowtie", Name: "Stripe Bowtie", Image: "027-Extras/stripe_bowtie.png", Thumbnail: "027-Extras/stripe_bowtie_thumbnail.png"},
+				{ID: "camera", Name: "Camera", Image: "027-Extras/camera.png", Thumbnail: "027-Extras/camera_thumbnail.png"},
+				{ID: "captain_america", Name: "Captain America", Image: "027-Extras/captain_america.png", Thumbnail: "027-Extras/captain_america_thumbnail.png"},
+				{ID: "cellphone", Name: "Cellphone", Image: "027-Extras/cellphone.png", Thumbnail: "027-Extras/cellphone_thumbnail.png"},
+				{ID: "coffee", Name: "Coffee", Image: "027-Extras/coffee.png", Thumbnail: "027-Extras/coffee_thumbnail.png"},
+				{ID: "gamer", Name: "Gamer", Image: "027-Extras/gamer.png", Thumbnail: "027-Extras/gamer_thumbnail.png"},
+				{ID: "heart_lolli", Name: "Heart Lolli", Image: "027-Extras/heart_lolli.png", Thumbnail: "027-Extras/heart_lolli_thumbnail.png"},
+				{ID: "laptop", Name: "Laptop", Image: "027-Extras/laptop.png", Thumbnail: "027-Extras/laptop_thumbnail.png"},
+				{ID: "lightsaber", Name: "Lightsaber", Image: "027-Extras/lightsaber.png", Thumbnail: "027-Extras/lightsaber_thumbnail.png"},
+				{ID: "magic_wand", Name: "Magic Wand", Image: "027-Extras/magic_wand.png", Thumbnail: "027-Extras/magic_wand_thumbnail.png"},
+				{ID: "popcorn", Name: "Popcorn", Image: "027-Extras/popcorn.png", Thumbnail: "027-Extras/popcorn_thumbnail.png"},
+				{ID: "soda", Name: "Soda", Image: "027-Extras/soda.png", Thumbnail: "027-Extras/soda_thumbnail.png"},
+				{ID: "to_go_coffee", Name: "To Go Coffee", Image: "027-Extras/to_go_coffee.png", Thumbnail: "027-Extras/to_go_coffee_thumbnail.png"},
+				{ID: "watch", Name: "Watch", Image: "027-Extras/watch.png", Thumbnail: "027-Extras/watch_thumbnail.png"},
+				{ID: "Large_black_yellow_bow", Name: "Large Black Yellow Bow", Image: "027-Extras/Large_black_yellow_bow.png", Thumbnail: "027-Extras/Large_black_yellow_bow_thumbnail.png"},
+				{ID: "moustache_pipe", Name: "Moustache Pipe", Image: "027-Extras/moustache_pipe.png", Thumbnail: "027-Extras/moustache_pipe_thumbnail.png"},
+				{ID: "red_polkadot_bow", Name: "Red Polkadot Bow", Image: "027-Extras/red_polkadot_bow.png", Thumbnail: "027-Extras/red_polkadot_bow_thumbnail.png"},
+				{ID: "steampunk_glasses", Name: "Steampunk Glasses", Image: "027-Extras/steampunk_glasses.png", Thumbnail: "027-Extras/steampunk_glasses_thumbnail.png"},
+				{ID: "unicorn_horn_pink", Name: "Unicorn Horn Pink", Image: "027-Extras/unicorn_horn_pink.png", Thumbnail: "027-Extras/unicorn_horn_pink_thumbnail.png"},
+				{ID: "valentines", Name: "Valentines", Image: "027-Extras/valentines.png", Thumbnail: "027-Extras/valentines_thumbnail.png"},
+				{ID: "yellow_polkadot_bow", Name: "Yellow Polkadot Bow", Image: "027-Extras/yellow_polkadot_bow.png", Thumbnail: "027-Extras/yellow_polkadot_bow_thumbnail.png"},
+			},
+		},
+	},
+	Rules: []*gopher.Rule{
+		{
+			If:       gopher.Ref{Category: "hats_and_hair_accessories", Option: "bat_gopher"},
+			Excludes: []gopher.Ref{{Category: "shirts", Option: ""}},
+		},
+		{
+			If:       gopher.Ref{Category: "hats_and_hair_accessories", Option: "gobuffalo_costume"},
+			Excludes: []gopher.Ref{{Category: "shirts", Option: ""}},
+		},
+		{
+			If:       gopher.Ref{Category: "hats_and_hair_accessories", Option: "stay_puft"},
+			Excludes: []gopher.Ref{{Category: "shirts", Option: ""}},
+		},
+		{
+			If:       gopher.Ref{Category: "hats_and_hair_accessories", Option: "bat_gopher"},
+			Excludes: []gopher.Ref{{Category: "hair", Option: ""}},
+		},
+		{
+			If:       gopher.Ref{Category: "hats_and_hair_accessories", Option: "gobuffalo_costume"},
+			Excludes: []gopher.Ref{{Category: "hair", Option: ""}},
+		},
+		{
+			If:       gopher.Ref{Category: "hats_and_hair_accessories", Option: "beanie"},
+			Excludes: []gopher.Ref{{Category: "hair", Option: "blue_ear_afro"}, {Category: "hair", Option: "brown_hawk"}, {Category: "hair", Option: "brown_mohawk"}, {Category: "hair", Option: "man_bun"}, {Category: "hair", Option: "pink_ear_afro"}, {Category: "hair", Option: "pink_unicorn"}, {Category: "hair", Option: "rainbow_unicorn"}, {Category: "hair", Option: "red_mohawk"}},
+		},
+		{
+			If:       gopher.Ref{Category: "hats_and_hair_accessories", Option: "birthday_hat"},
+			Excludes: []gopher.Ref{{Category: "hair", Option: "blue_ear_afro"}, {Category: "hair", Option: "brown_hawk"}, {Category: "hair", Option: "brown_mohawk"}, {Category: "hair", Option: "man_bun"}, {Category: "hair", Option: "pink_ear_afro"}, {Category: "hair", Option: "pink_unicorn"}, {Category: "hair", Option: "rainbow_unicorn"}, {Category: "hair", Option: "red_mohawk"}},
+		},
+		{
+			If:       gopher.Ref{Category: "hats_and_hair_accessories", Option: "graduation"},
+			Excludes: []gopher.Ref{{Category: "hair", Option: "blue_ear_afro"}, {Category: "hair", Option: "brown_hawk"}, {Category: "hair", Option: "brown_mohawk"}, {Category: "hair", Option: "man_bun"}, {Category: "hair", Option: "pink_ear_afro"}, {Category: "hair", Option: "pink_unicorn"}, {Category: "hair", Option: "rainbow_unicorn"}, {Category: "hair", Option: "red_mohawk"}},
+		},
+		{
+			If:       gopher.Ref{Category: "hats_and_hair_accessories", Option: "moar_viking"},
+			Excludes: []gopher.Ref{{Category: "hair", Option: "blue_ear_afro"}, {Category: "hair", Option: "brown_hawk"}, {Category: "hair", Option: "brown_mohawk"}, {Category: "hair", Option: "man_bun"}, {Category: "hair", Option: "pink_ear_afro"}, {Category: "hair", Option: "pink_unicorn"}, {Category: "hair", Option: "rainbow_unicorn"}, {Category: "hair", Option: "red_mohawk"}},
+		},
+		{
+			If:       gopher.Ref{Category: "hats_and_hair_accessories", Option: "pirate_hat"},
+			Excludes: []gopher.Ref{{Category: "hair", Option: "blue_ear_afro"}, {Category: "hair", Option: "brown_hawk"}, {Category: "hair", Option: "brown_mohawk"}, {Category: "hair", Option: "man_bun"}, {Category: "hair", Option: "pink_ear_afro"}, {Category: "hair", Option: "pink_unicorn"}, {Category: "hair", Option: "rainbow_unicorn"}, {Category: "hair", Option: "red_mohawk"}},
+		},
+		{
+			If:       gopher.Ref{Category: "hats_and_hair_accessories", Option: "ship_captain"},
+			Excludes: []gopher.Ref{{Category: "hair", Option: "blue_ear_afro"}, {Category: "hair", Option: "brown_hawk"}, {Category: "hair", Option: "brown_mohawk"}, {Category: "hair", Option: "man_bun"}, {Category: "hair", Option: "pink_ear_afro"}, {Category: "hair", Option: "pink_unicorn"}, {Category: "hair", Option: "rainbow_unicorn"}, {Category: "hair", Option: "red_mohawk"}},
+		},
+		{
+			If:       gopher.Ref{Category: "hats_and_hair_accessories", Option: "stay_puft"},
+			Excludes: []gopher.Ref{{Category: "hair", Option: "blue_ear_afro"}, {Category: "hair", Option: "brown_hawk"}, {Category: "hair", Option: "brown_mohawk"}, {Category: "hair", Option: "man_bun"}, {Category: "hair", Option: "pink_ear_afro"}, {Category: "hair", Option: "pink_unicorn"}, {Category: "hair", Option: "rainbow_unicorn"}, {Category: "hair", Option: "red_mohawk"}},
+		},
+		{
+			If:       gopher.Ref{Category: "hats_and_hair_accessories", Option: "steampunk_tophat"},
+			Excludes: []gopher.Ref{{Category: "hair", Option: "blue_ear_afro"}, {Category: "hair", Option: "brown_hawk"}, {Category: "hair", Option: "brown_mohawk"}, {Category: "hair", Option: "man_bun"}, {Category: "hair", Option: "pink_ear_afro"}, {Category: "hair", Option: "pink_unicorn"}, {Category: "hair", Option: "rainbow_unicorn"}, {Category: "hair", Option: "red_mohawk"}},
+		},
+		{
+			If:       gopher.Ref{Category: "hats_and_hair_accessories", Option: "the_bill_kennedy"},
+			Excludes: []gopher.Ref{{Category: "hair", Option: "blue_ear_afro"}, {Category: "hair", Option: "brown_hawk"}, {Category: "hair", Option: "brown_mohawk"}, {Category: "hair", Option: "man_bun"}, {Category: "hair", Option: "pink_ear_afro"}, {Category: "hair", Option: "pink_unicorn"}, {Category: "hair", Option: "rainbow_unicorn"}, {Category: "hair", Option: "red_mohawk"}},
+		},
+		{
+			If:       gopher.Ref{Category: "hats_and_hair_accessories", Option: "viking_hat"},
+			Excludes: []gopher.Ref{{Category: "hair", Option: "blue_ear_afro"}, {Category: "hair", Option: "brown_hawk"}, {Category: "hair", Option: "brown_mohawk"}, {Category: "hair", Option: "man_bun"}, {Category: "hair", Option: "pink_ear_afro"}, {Category: "hair", Option: "pink_unicorn"}, {Category: "hair", Option: "rainbow_unicorn"}, {Category: "hair", Option: "red_mohawk"}},
+		},
+		{
+			If:       gopher.Ref{Category: "hats_and_hair_accessories", Option: "wicked_tophat"},
+			Excludes: []gopher.Ref{{Category: "hair", Option: "blue_ear_afro"}, {Category: "hair", Option: "brown_hawk"}, {Category: "hair", Option: "brown_mohawk"}, {Category: "hair", Option: "man_bun"}, {Category: "hair", Option: "pink_ear_afro"}, {Category: "hair", Option: "pink_unicorn"}, {Category: "hair", Option: "rainbow_unicorn"}, {Category: "hair", Option: "red_mohawk"}},
+		},
+		{
+			If:       gopher.Ref{Category: "hats_and_hair_accessories", Option: "yarmulke"},
+			Excludes: []gopher.Ref{{Category: "hair", Option: "blue_ear_afro"}, {Category: "hair", Option: "brown_hawk"}, {Category: "hair", Option: "brown_mohawk"}, {Category: "hair", Option: "man_bun"}, {Category: "hair", Option: "pink_ear_afro"}, {Category: "hair", Option: "pink_unicorn"}, {Category: "hair", Option: "rainbow_unicorn"}, {Category: "hair", Option: "red_mohawk"}},
+		},
+		{
+			If:       gopher.Ref{Category: "hats_and_hair_accessories", Option: "unicorn_horn_pink"},
+			Excludes: []gopher.Ref{{Category: "hair", Option: "pink_unicorn"}, {Category: "hair", Option: "rainbow_unicorn"}},
+		},
+		{
+			If:       gopher.Ref{Category: "extras", Option: "unicorn_horn_pink"},
+			Excludes: []gopher.Ref{{Category: "hair", Option: "pink_unicorn"}, {Category: "hair", Option: "rainbow_unicorn"}},
+		},
+		{
+			If:       gopher.Ref{Category: "extras", Option: "camera"},
+			Excludes: []gopher.Ref{{Category: "extras", Option: "camera"}, {Category: "extras", Option: "captain_america"}, {Category: "extras", Option: "cellphone"}, {Category: "extras", Option: "coffee"}, {Category: "extras", Option: "gamer"}, {Category: "extras", Option: "heart_lolli"}, {Category: "extras", Option: "laptop"}, {Category: "extras", Option: "lightsaber"}, {Category: "extras", Option: "magic_wand"}, {Category: "extras", Option: "popcorn"}, {Category: "extras", Option: "soda"}, {Category: "extras", Option: "to_go_coffee"}},
+		},
+		{
+			If:       gopher.Ref{Category: "extras", Option: "captain_america"},
+			Excludes: []gopher.Ref{{Category: "extras", Option: "camera"}, {Category: "extras", Option: "captain_america"}, {Category: "extras", Option: "cellphone"}, {Category: "extras", Option: "coffee"}, {Category: "extras", Option: "gamer"}, {Category: "extras", Option: "heart_lolli"}, {Category: "extras", Option: "laptop"}, {Category: "extras", Option: "lightsaber"}, {Category: "extras", Option: "magic_wand"}, {Category: "extras", Option: "popcorn"}, {Category: "extras", Option: "soda"}, {Category: "extras", Option: "to_go_coffee"}},
+		},
+		{
+			If:       gopher.Ref{Category: "extras", Option: "cellphone"},
+			Excludes: []gopher.Ref{{Category: "extras", Option: "camera"}, {Category: "extras", Option: "captain_america"}, {Category: "extras", Option: "cellphone"}, {Category: "extras", Option: "coffee"}, {Category: "extras", Option: "gamer"}, {Category: "extras", Option: "heart_lolli"}, {Category: "extras", Option: "laptop"}, {Category: "extras", Option: "lightsaber"}, {Category: "extras", Option: "magic_wand"}, {Category: "extras", Option: "popcorn"}, {Category: "extras", Option: "soda"}, {Category: "extras", Option: "to_go_coffee"}},
+		},
+		{
+			If:       gopher.Ref{Category: "extras", Option: "coffee"},
+			Excludes: []gopher.Ref{{Category: "extras", Option: "camera"}, {Category: "extras", Option: "captain_america"}, {Category: "extras", Option: "cellphone"}, {Category: "extras", Option: "coffee"}, {Category: "extras", Option: "gamer"}, {Category: "extras", Option: "heart_lolli"}, {Category: "extras", Option: "laptop"}, {Category: "extras", Option: "lightsaber"}, {Category: "extras", Option: "magic_wand"}, {Category: "extras", Option: "popcorn"}, {Category: "extras", Option: "soda"}, {Category: "extras", Option: "to_go_coffee"}},
+		},
+		{
+			If:       gopher.Ref{Category: "extras", Option: "gamer"},
+			Excludes: []gopher.Ref{{Category: "extras", Option: "camera"}, {Category: "extras", Option: "captain_america"}, {Category: "extras", Option: "cellphone"}, {Category: "extras", Option: "coffee"}, {Category: "extras", Option: "gamer"}, {Category: "extras", Option: "heart_lolli"}, {Category: "extras", Option: "laptop"}, {Category: "extras", Option: "lightsaber"}, {Category: "extras", Option: "magic_wand"}, {Category: "extras", Option: "popcorn"}, {Category: "extras", Option: "soda"}, {Category: "extras", Option: "to_go_coffee"}},
+		},
+		{
+			If:       gopher.Ref{Category: "extras", Option: "heart_lolli"},
+			Excludes: []gopher.Ref{{Category: "extras", Option: "camera"}, {Category: "extras", Option: "captain_america"}, {Category: "extras", Option: "cellphone"}, {Category: "extras", Option: "coffee"}, {Category: "extras", Option: "gamer"}, {Category: "extras", Option: "heart_lolli"}, {Category: "extras", Option: "laptop"}, {Category: "extras", Option: "lightsaber"}, {Category: "extras", Option: "magic_wand"}, {Category: "extras", Option: "popcorn"}, {Category: "extras", Option: "soda"}, {Category: "extras", Option: "to_go_coffee"}},
+		},
+		{
+			If:       gopher.Ref{Category: "extras", Option: "laptop"},
+			Excludes: []gopher.Ref{{Category: "extras", Option: "camera"}, {Category: "extras", Option: "captain_america"}, {Category: "extras", Option: "cellphone"}, {Category: "extras", Option: "coffee"}, {Category: "extras", Option: "gamer"}, {Category: "extras", Option: "heart_lolli"}, {Category: "extras", Option: "laptop"}, {Category: "extras", Option: "lightsaber"}, {Category: "extras", Option: "magic_wand"}, {Category: "extras", Option: "popcorn"}, {Category: "extras", Option: "soda"}, {Category: "extras", Option: "to_go_coffee"}},
+		},
+		{
+			If:       gopher.Ref{Category: "extras", Option: "lightsaber"},
+			Excludes: []gopher.Ref{{Category: "extras", Option: "camera"}, {Category: "extras", Option: "captain_america"}, {Category: "extras", Option: "cellphone"}, {Category: "extras", Option: "coffee"}, {Category: "extras", Option: "gamer"}, {Category: "extras", Option: "heart_lolli"}, {Category: "extras", Option: "laptop"}, {Category: "extras", Option: "lightsaber"}, {Category: "extras", Option: "magic_wand"}, {Category: "extras", Option: "popcorn"}, {Category: "extras", Option: "soda"}, {Category: "extras", Option: "to_go_coffee"}},
+		},
+		{
+			If:       gopher.Ref{Category: "extras", Option: "magic_wand"},
+			Excludes: []gopher.Ref{{Category: "extras", Option: "camera"}, {Category: "extras", Option: "captain_america"}, {Category: "extras", Option: "cellphone"}, {Category: "extras", Option: "coffee"}, {Category: "extras", Option: "gamer"}, {Category: "extras", Option: "heart_lolli"}, {Category: "extras", Option: "laptop"}, {Category: "extras", Option: "lightsaber"}, {Category: "extras", Option: "magic_wand"}, {Category: "extras", Option: "popcorn"}, {Category: "extras", Option: "soda"}, {Category: "extras", Option: "to_go_coffee"}},
+		},
+		{
+			If:       gopher.Ref{Category: "extras", Option: "popcorn"},
+			Excludes: []gopher.Ref{{Category: "extras", Option: "camera"}, {Category: "extras", Option: "captain_america"}, {Category: "extras", Option: "cellphone"}, {Category: "extras", Option: "coffee"}, {Category: "extras", Option: "gamer"}, {Category: "extras", Option: "heart_lolli"}, {Category: "extras", Option: "laptop"}, {Category: "extras", Option: "lightsaber"}, {Category: "extras", Option: "magic_wand"}, {Category: "extras", Option: "popcorn"}, {Category: "extras", Option: "soda"}, {Category: "extras", Option: "to_go_coffee"}},
+		},
+		{
+			If:       gopher.Ref{Category: "extras", Option: "soda"},
+			Excludes: []gopher.Ref{{Category: "extras", Option: "camera"}, {Category: "extras", Option: "captain_america"}, {Category: "extras", Option: "cellphone"}, {Category: "extras", Option: "coffee"}, {Category: "extras", Option: "gamer"}, {Category: "extras", Option: "heart_lolli"}, {Category: "extras", Option: "laptop"}, {Category: "extras", Option: "lightsaber"}, {Category: "extras", Option: "magic_wand"}, {Category: "extras", Option: "popcorn"}, {Category: "extras", Option: "soda"}, {Category: "extras", Option: "to_go_coffee"}},
+		},
+		{
+			If:       gopher.Ref{Category: "extras", Option: "to_go_coffee"},
+			Excludes: []gopher.Ref{{Category: "extras", Option: "camera"}, {Category: "extras", Option: "captain_america"}, {Category: "extras", Option: "cellphone"}, {Category: "extras", Option: "coffee"}, {Category: "extras", Option: "gamer"}, {Category: "extras", Option: "heart_lolli"}, {Category: "extras", Option: "laptop"}, {Category: "extras", Option: "lightsaber"}, {Category: "extras", Option: "magic_wand"}, {Category: "extras", Option: "popcorn"}, {Category: "extras", Option: "soda"}, {Category: "extras", Option: "to_go_coffee"}},
+		},
+		{
+			If:       gopher.Ref{Category: "extras", Option: "bowtie"},
+			Excludes: []gopher.Ref{{Category: "extras", Option: "bowtie"}, {Category: "extras", Option: "stripe_bowtie"}},
+		},
+		{
+			If:       gopher.Ref{Category: "extras", Option: "stripe_bowtie"},
+			Excludes: []gopher.Ref{{Category: "extras", Option: "bowtie"}, {Category: "extras", Option: "stripe_bowtie"}},
+		},
+		{
+			If:       gopher.Ref{Category: "extras", Option: "Large_black_yellow_bow"},
+			Excludes: []gopher.Ref{{Category: "extras", Option: "Large_black_yellow_bow"}, {Category: "extras", Option: "red_polkadot_bow"}, {Category: "extras", Option: "yellow_polkadot_bow"}},
+		},
+		{
+			If:       gopher.Ref{Category: "extras", Option: "red_polkadot_bow"},
+			Excludes: []gopher.Ref{{Category: "extras", Option: "Large_black_yellow_bow"}, {Category: "extras", Option: "red_polkadot_bow"}, {Category: "extras", Option: "yellow_polkadot_bow"}},
+		},
+		{
+			If:       gopher.Ref{Category: "extras", Option: "yellow_polkadot_bow"},
+			Excludes: []gopher.Ref{{Category: "extras", Option: "Large_black_yellow_bow"}, {Category: "extras", Option: "red_polkadot_bow"}, {Category: "extras", Option: "yellow_polkadot_bow"}},
+		},
+		{
+			If:       gopher.Ref{Category: "hair", Option: "blonde_hair_blue_ears"},
+			Requires: []gopher.Ref{{Category: "body", Option: "blue_gopher"}},
+		},
+		{
+			If:       gopher.Ref{Category: "hair", Option: "blue_ear_afro"},
+			Requires: []gopher.Ref{{Category: "body", Option: "blue_gopher"}},
+		},
+		{
+			If:       gopher.Ref{Category: "hair", Option: "blue_ear_curly_hair"},
+			Requires: []gopher.Ref{{Category: "body", Option: "blue_gopher"}},
+		},
+		{
+			If:       gopher.Ref{Category: "hair", Option: "brown_hair_blue_ears"},
+			Requires: []gopher.Ref{{Category: "body", Option: "blue_gopher"}},
+		},
+		{
+			If:       gopher.Ref{Category: "hair", Option: "brown_hair_ears_blue"},
+			Requires: []gopher.Ref{{Category: "body", Option: "blue_gopher"}},
+		},
+		{
+			If:       gopher.Ref{Category: "hair", Option: "pink_hair_blue_ears"},
+			Requires: []gopher.Ref{{Category: "body", Option: "blue_gopher"}},
+		},
+		{
+			If:       gopher.Ref{Category: "hair", Option: "red_hair_blue_ears"},
+			Requires: []gopher.Ref{{Category: "body", Option: "blue_gopher"}},
+		},
+		{
+			If:       gopher.Ref{Category: "hair", Option: "blonde_hair_pink_ears"},
+			Requires: []gopher.Ref{{Category: "body", Option: "pink_gopher"}},
+		},
+		{
+			If:       gopher.Ref{Category: "hair", Option: "brown_hair_pink_ears"},
+			Requires: []gopher.Ref{{Category: "body", Option: "pink_gopher"}},
+		},
+		{
+			If:       gopher.Ref{Category: "hair", Option: "pink_ear_afro"},
+			Requires: []gopher.Ref{{Category: "body", Option: "pink_gopher"}},
+		},
+		{
+			If:       gopher.Ref{Category: "hair", Option: "pink_ear_curly_hair"},
+			Requires: []gopher.Ref{{Category: "body", Option: "pink_gopher"}},
+		},
+		{
+			If:       gopher.Ref{Category: "hair", Option: "pink_hair_pink_ears"},
+			Requires: []gopher.Ref{{Category: "body", Option: "pink_gopher"}},
+		},
+		{
+			If:       gopher.Ref{Category: "hair", Option: "red_hair_pink_ears"},
+			Requires: []gopher.Ref{{Category: "body", Option: "pink_gopher"}},
+		},
+		{
+			If:      gopher.Ref{Category: "hair", Option: "combed_left_red_hair"},
+			Prefers: []gopher.Ref{{Category: "facial_hair", Option: "full_red_beard"}, {Category: "facial_hair", Option: "full_redish_beard"}, {Category: "facial_hair", Option: "moustache_red"}, {Category: "facial_hair", Option: "red_beard"}, {Category: "facial_hair", Option: "red_soul_patch"}, {Category: "facial_hair", Option: "short_copper_beard"}, {Category: "facial_hair", Option: "short_full_red_beard"}},
+		},
+		{
+			If:      gopher.Ref{Category: "hair", Option: "curly_red"},
+			Prefers: []gopher.Ref{{Category: "facial_hair", Option: "full_red_beard"}, {Category: "facial_hair", Option: "full_redish_beard"}, {Category: "facial_hair", Option: "moustache_red"}, {Category: "facial_hair", Option: "red_beard"}, {Category: "facial_hair", Option: "red_soul_patch"}, {Category: "facial_hair", Option: "short_copper_beard"}, {Category: "facial_hair", Option: "short_full_red_beard"}},
+		},
+		{
+			If:      gopher.Ref{Category: "hair", Option: "hair_red"},
+			Prefers: []gopher.Ref{{Category: "facial_hair", Option: "full_red_beard"}, {Category: "facial_hair", Option: "full_redish_beard"}, {Category: "facial_hair", Option: "moustache_red"}, {Category: "facial_hair", Option: "red_beard"}, {Category: "facial_hair", Option: "red_soul_patch"}, {Category: "facial_hair", Option: "short_copper_beard"}, {Category: "facial_hair", Option: "short_full_red_beard"}},
+		},
+		{
+			If:      gopher.Ref{Category: "hair", Option: "red_bangs"},
+			Prefers: []gopher.Ref{{Category: "facial_hair", Option: "full_red_beard"}, {Category: "facial_hair", Option: "full_redish_beard"}, {Category: "facial_hair", Option: "moustache_red"}, {Category: "facial_hair", Option: "red_beard"}, {Category: "facial_hair", Option: "red_soul_patch"}, {Category: "facial_hair", Option: "short_copper_beard"}, {Category: "facial_hair", Option: "short_full_red_beard"}},
+		},
+		{
+			If:      gopher.Ref{Category: "hair", Option: "red_hair_blue_ears"},
+			Prefers: []gopher.Ref{{Category: "facial_hair", Option: "full_red_beard"}, {Category: "facial_hair", Option: "full_redish_beard"}, {Category: "facial_hair", Option: "moustache_red"}, {Category: "facial_hair", Option: "red_beard"}, {Category: "facial_hair", Option: "red_soul_patch"}, {Category: "facial_hair", Option: "short_copper_beard"}, {Category: "facial_hair", Option: "short_full_red_beard"}},
+		},
+		{
+			If:      gopher.Ref{Category: "hair", Option: "red_hair_pink_ears"},
+			Prefers: []gopher.Ref{{Category: "facial_hair", Option: "full_red_beard"}, {Category: "facial_hair", Option: "full_redish_beard"}, {Category: "facial_hair", Option: "moustache_red"}, {Category: "facial_hair", Option: "red_beard"}, {Category: "facial_hair", Option: "red_soul_patch"}, {Category: "facial_hair", Option: "short_copper_beard"}, {Category: "facial_hair", Option: "short_full_red_beard"}},
+		},
+		{
+			If:      gopher.Ref{Category: "hair", Option: "red_hipster_hair"},
+			Prefers: []gopher.Ref{{Category: "facial_hair", Option: "full_red_beard"}, {Category: "facial_hair", Option: "full_redish_beard"}, {Category: "facial_hair", Option: "moustache_red"}, {Category: "facial_hair", Option: "red_beard"}, {Category: "facial_hair", Option: "red_soul_patch"}, {Category: "facial_hair", Option: "short_copper_beard"}, {Category: "facial_hair", Option: "short_full_red_beard"}},
+		},
+		{
+			If:      gopher.Ref{Category: "hair", Option: "red_mohawk"},
+			Prefers: []gopher.Ref{{Category: "facial_hair", Option: "full_red_beard"}, {Category: "facial_hair", Option: "full_redish_beard"}, {Category: "facial_hair", Option: "moustache_red"}, {Category: "facial_hair", Option: "red_beard"}, {Category: "facial_hair", Option: "red_soul_patch"}, {Category: "facial_hair", Option: "short_copper_beard"}, {Category: "facial_hair", Option: "short_full_red_beard"}},
+		},
+		{
+			If:      gopher.Ref{Category: "hair", Option: "red_swoop_hair"},
+			Prefers: []gopher.Ref{{Category: "facial_hair", Option: "full_red_beard"}, {Category: "facial_hair", Option: "full_redish_beard"}, {Category: "facial_hair", Option: "moustache_red"}, {Category: "facial_hair", Option: "red_beard"}, {Category: "facial_hair", Option: "red_soul_patch"}, {Category: "facial_hair", Option: "short_copper_beard"}, {Category: "facial_hair", Option: "short_full_red_beard"}},
+		},
+		{
+			If:      gopher.Ref{Category: "hair", Option: "ash_blonde_hair"},
+			Prefers: []gopher.Ref{{Category: "facial_hair", Option: "blonde_beard"}, {Category: "facial_hair", Option: "blonde_moustache"}, {Category: "facial_hair", Option: "blonde_stache"}, {Category: "facial_hair", Option: "detailed_blonde_beard"}, {Category: "facial_hair", Option: "full_ash_blonde_beard"}, {Category: "facial_hair", Option: "full_blonde_beard"}, {Category: "facial_hair", Option: "short_blonde_beard"}, {Category: "facial_hair", Option: "short_full_blonde_beard"}},
+		},
+		{
+			If:      gopher.Ref{Category: "hair", Option: "blonde_bangs"},
+			Prefers: []gopher.Ref{{Category: "facial_hair", Option: "blonde_beard"}, {Category: "facial_hair", Option: "blonde_moustache"}, {Category: "facial_hair", Option: "blonde_stache"}, {Category: "facial_hair", Option: "detailed_blonde_beard"}, {Category: "facial_hair", Option: "full_ash_blonde_beard"}, {Category: "facial_hair", Option: "full_blonde_beard"}, {Category: "facial_hair", Option: "short_blonde_beard"}, {Category: "facial_hair", Option: "short_full_blonde_beard"}},
+		},
+		{
+			If:      gopher.Ref{Category: "hair", Option: "blonde_hair_blue_ears"},
+			Prefers: []gopher.Ref{{Category: "facial_hair", Option: "blonde_beard"}, {Category: "facial_hair", Option: "blonde_moustache"}, {Category: "facial_hair", Option: "blonde_stache"}, {Category: "facial_hair", Option: "detailed_blonde_beard"}, {Category: "facial_hair", Option: "full_ash_blonde_beard"}, {Category: "facial_hair", Option: "full_blonde_beard"}, {Category: "facial_hair", Option: "short_blonde_beard"}, {Category: "facial_hair", Option: "short_full_blonde_beard"}},
+		},
+		{
+			If:      gopher.Ref{Category: "hair", Option: "blonde_hair_pink_ears"},
+			Prefers: []gopher.Ref{{Category: "facial_hair", Option: "blonde_beard"}, {Category: "facial_hair", Option: "blonde_moustache"}, {Category: "facial_hair", Option: "blonde_stache"}, {Category: "facial_hair", Option: "detailed_blonde_beard"}, {Category: "facial_hair", Option: "full_ash_blonde_beard"}, {Category: "facial_hair", Option: "full_blonde_beard"}, {Category: "facial_hair", Option: "short_blonde_beard"}, {Category: "facial_hair", Option: "short_full_blonde_beard"}},
+		},
+		{
+			If:      gopher.Ref{Category: "hair", Option: "blonde_swoop_hair"},
+			Prefers: []gopher.Ref{{Category: "facial_hair", Option: "blonde_beard"}, {Category: "facial_hair", Option: "blonde_moustache"}, {Category: "facial_hair", Option: "blonde_stache"}, {Category: "facial_hair", Option: "detailed_blonde_beard"}, {Category: "facial_hair", Option: "full_ash_blonde_beard"}, {Category: "facial_hair", Option: "full_blonde_beard"}, {Category: "facial_hair", Option: "short_blonde_beard"}, {Category: "facial_hair", Option: "short_full_blonde_beard"}},
+		},
+		{
+			If:      gopher.Ref{Category: "hair", Option: "curly_blonde"},
+			Prefers: []gopher.Ref{{Category: "facial_hair", Option: "blonde_beard"}, {Category: "facial_hair", Option: "blonde_moustache"}, {Category: "facial_hair", Option: "blonde_stache"}, {Category: "facial_hair", Option: "detailed_blonde_beard"}, {Category: "facial_hair", Option: "full_ash_blonde_beard"}, {Category: "facial_hair", Option: "full_blonde_beard"}, {Category: "facial_hair", Option: "short_blonde_beard"}, {Category: "facial_hair", Option: "short_full_blonde_beard"}},
+		},
+		{
+			If:      gopher.Ref{Category: "hair", Option: "hair_blonde"},
+			Prefers: []gopher.Ref{{Category: "facial_hair", Option: "blonde_beard"}, {Category: "facial_hair", Option: "blonde_moustache"}, {Category: "facial_hair", Option: "blonde_stache"}, {Category: "facial_hair", Option: "detailed_blonde_beard"}, {Category: "facial_hair", Option: "full_ash_blonde_beard"}, {Category: "facial_hair", Option: "full_blonde_beard"}, {Category: "facial_hair", Option: "short_blonde_beard"}, {Category: "facial_hair", Option: "short_full_blonde_beard"}},
+		},
+		{
+			If:      gopher.Ref{Category: "hair", Option: "long_blonde_hair"},
+			Prefers: []gopher.Ref{{Category: "facial_hair", Option: "blonde_beard"}, {Category: "facial_hair", Option: "blonde_moustache"}, {Category: "facial_hair", Option: "blonde_stache"}, {Category: "facial_hair", Option: "detailed_blonde_beard"}, {Category: "facial_hair", Option: "full_ash_blonde_beard"}, {Category: "facial_hair", Option: "full_blonde_beard"}, {Category: "facial_hair", Option: "short_blonde_beard"}, {Category: "facial_hair", Option: "short_full_blonde_beard"}},
+		},
+	},
+}
@@ -0,0 +1,342 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+// Package lint checks an artwork directory for broken or inconsistent assets
+package lint
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/myitcv/gopherize.me/artwork"
+	"github.com/myitcv/gopherize.me/artwork/scan"
+	"github.com/myitcv/gopherize.me/gopher"
+)
+
+// Severity is how serious a problem is
+type Severity int
+
+const (
+	// Error is a problem that must be fixed before the artwork is used
+	Error Severity = iota
+
+	// Warning is a problem that should be fixed but does not stop the artwork
+	// from being used, e.g. missing metadata
+	Warning
+)
+
+func (s Severity) String() string {
+	switch s {
+	case Error:
+		return "error"
+	case Warning:
+		return "warning"
+	}
+
+	return fmt.Sprintf("Severity(%d)", int(s))
+}
+
+// Problem is a single problem found in an artwork directory
+type Problem struct {
+	// Path is the slash-separated path, relative to the artwork root, of the
+	// file to which the problem relates
+	Path string
+
+	Severity Severity
+
+	Msg string
+}
+
+func (p Problem) String() string {
+	if p.Severity == Warning {
+		return p.Path + ": warning: " + p.Msg
+	}
+
+	return p.Path + ": " + p.Msg
+}
+
+type linter struct {
+	root     string
+	manifest *gopher.Manifest
+	problems []Problem
+}
+
+// Dir checks the artwork directory root, returning the problems found sorted
+// by path. An error is returned only if root cannot be scanned at all.
+func Dir(root string) ([]Problem, error) {
+	res, err := scan.Dir(root)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &linter{
+		root:     root,
+		manifest: res.Manifest,
+	}
+
+	for _, p := range res.Orphans {
+		l.errorf(p, "thumbnail has no full-size image")
+	}
+
+	for _, p := range res.Ignored {
+		l.errorf(p, "not a PNG image named [A-Za-z0-9_]+.png")
+	}
+
+	for _, p := range res.Unknown {
+		l.errorf(p, "described in %v but image does not exist", scan.MetaFile)
+	}
+
+	l.checkThumbnails()
+	l.checkDuplicates()
+	l.checkPlacement()
+	l.checkSizes()
+	l.checkMeta()
+
+	sort.SliceStable(l.problems, func(i, j int) bool {
+		return l.problems[i].Path < l.problems[j].Path
+	})
+
+	return l.problems, nil
+}
+
+func (l *linter) errorf(p string, format string, args ...interface{}) {
+	l.add(p, Error, format, args...)
+}
+
+func (l *linter) warnf(p string, format string, args ...interface{}) {
+	l.add(p, Warning, format, args...)
+}
+
+func (l *linter) add(p string, s Severity, format string, args ...interface{}) {
+	l.problems = append(l.problems, Problem{
+		Path:     p,
+		Severity: s,
+		Msg:      fmt.Sprintf(format, args...),
+	})
+}
+
+func (l *linter) checkThumbnails() {
+	for _, c := range l.manifest.Categories {
+		for _, o := range c.Options {
+			if o.Thumbnail == "" {
+				l.errorf(o.Image, "image has no thumbnail")
+			}
+		}
+	}
+}
+
+// checkMeta flags options that the metadata file of their category does not
+// credit or license
+func (l *linter) checkMeta() {
+	for _, c := range l.manifest.Categories {
+		for _, o := range c.Options {
+			switch {
+			case o.Artist == "" && o.Licence == "":
+				l.warnf(o.Image, "no artist or licence in %v", path.Join(c.Dir, scan.MetaFile))
+			case o.Artist == "":
+				l.warnf(o.Image, "no artist in %v", path.Join(c.Dir, scan.MetaFile))
+			case o.Licence == "":
+				l.warnf(o.Image, "no licence in %v", path.Join(c.Dir, scan.MetaFile))
+			}
+		}
+	}
+}
+
+// checkDuplicates flags option IDs that appear in more than one category, and
+// images whose contents are identical
+func (l *linter) checkDuplicates() {
+	ids := make(map[string]*gopher.Category)
+	sums := make(map[[sha256.Size]byte]string)
+
+	for _, c := range l.manifest.Categories {
+		for _, o := range c.Options {
+			if other, ok := ids[o.ID]; ok {
+				l.errorf(o.Image, "option %v also appears in %v", o.ID, other.Dir)
+				continue
+			}
+			ids[o.ID] = c
+
+			f, err := os.Open(filepath.Join(l.root, filepath.FromSlash(o.Image)))
+			if err != nil {
+				l.errorf(o.Image, "could not open: %v", err)
+				continue
+			}
+
+			h := sha256.New()
+			_, err = io.Copy(h, f)
+			f.Close()
+			if err != nil {
+				l.errorf(o.Image, "could not read: %v", err)
+				continue
+			}
+
+			var sum [sha256.Size]byte
+			copy(sum[:], h.Sum(nil))
+
+			if other, ok := sums[sum]; ok {
+				l.errorf(o.Image, "image is identical to %v", other)
+			} else {
+				sums[sum] = o.Image
+			}
+		}
+	}
+}
+
+// placementStopWords are words in category IDs that say nothing about the
+// options in that category; extras is a catch-all
+var placementStopWords = map[string]bool{
+	"and":    true,
+	"extras": true,
+}
+
+// placementAllowed are the options, as category-option, whose name mentions
+// another category but which belong where they are, e.g. goggles worn on a hat
+var placementAllowed = map[string]bool{
+	"extras-steampunk_glasses": true,
+}
+
+// checkPlacement flags options whose name mentions another category but not
+// their own, e.g. a hair option in the body category, unless they are listed
+// in placementAllowed
+func (l *linter) checkPlacement() {
+	words := make(map[*gopher.Category]map[string]bool)
+
+	for _, c := range l.manifest.Categories {
+		ws := make(map[string]bool)
+		for _, w := range strings.Split(c.ID, "_") {
+			if placementStopWords[w] {
+				continue
+			}
+			ws[w] = true
+			ws[strings.TrimSuffix(w, "s")] = true
+		}
+		words[c] = ws
+	}
+
+	mentions := func(o *gopher.Option, c *gopher.Category) bool {
+		for _, w := range strings.Split(strings.ToLower(o.ID), "_") {
+			if words[c][w] {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, c := range l.manifest.Categories {
+		for _, o := range c.Options {
+			if mentions(o, c) || placementAllowed[c.ID+"-"+o.ID] {
+				continue
+			}
+
+			// prefer the most specific category, i.e. the one with the fewest
+			// words
+			var best *gopher.Category
+
+			for _, oc := range l.manifest.Categories {
+				if oc == c || !mentions(o, oc) {
+					continue
+				}
+				if best == nil || len(words[oc]) < len(words[best]) {
+					best = oc
+				}
+			}
+
+			if best != nil {
+				l.errorf(o.Image, "name suggests it belongs in %v", best.Dir)
+			}
+		}
+	}
+}
+
+// checkSizes decodes every image, and checks that every layer has the same
+// canvas size as the body layers and that all thumbnails share one size
+func (l *linter) checkSizes() {
+	body := l.manifest.Category(gopher.BodyID)
+	if body == nil {
+		l.errorf(".", "no %v category, so layer sizes cannot be checked", gopher.BodyID)
+		body = &gopher.Category{}
+	}
+
+	var canvas *image.Point
+	for _, o := range body.Options {
+		s, ok := l.size(o.Image)
+		if !ok {
+			continue
+		}
+		if canvas == nil {
+			canvas = &s
+		} else if s != *canvas {
+			l.errorf(o.Image, "body layer is %v; other body layers are %v", s, *canvas)
+		}
+	}
+
+	thumbs := make(map[image.Point][]string)
+
+	addThumb := func(p string) {
+		if s, ok := l.size(p); ok {
+			thumbs[s] = append(thumbs[s], p)
+		}
+	}
+
+	for _, c := range l.manifest.Categories {
+		for _, o := range c.Options {
+			if c != body {
+				if s, ok := l.size(o.Image); ok && canvas != nil && s != *canvas {
+					l.errorf(o.Image, "layer is %v; body layers are %v", s, *canvas)
+				}
+			}
+			if o.Thumbnail != "" {
+				addThumb(o.Thumbnail)
+			}
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(l.root, artwork.NoneThumbnail)); err == nil {
+		addThumb(artwork.NoneThumbnail)
+	}
+
+	// the most common thumbnail size wins; ties are broken by the smallest
+	// size so that the result is stable
+	var want image.Point
+	for s, ps := range thumbs {
+		n := len(thumbs[want])
+		if len(ps) > n || len(ps) == n && (s.X < want.X || s.X == want.X && s.Y < want.Y) {
+			want = s
+		}
+	}
+
+	for s, ps := range thumbs {
+		if s == want {
+			continue
+		}
+		for _, p := range ps {
+			l.errorf(p, "thumbnail is %v; most thumbnails are %v", s, want)
+		}
+	}
+}
+
+// size decodes the image at p, returning its size
+func (l *linter) size(p string) (image.Point, bool) {
+	f, err := os.Open(filepath.Join(l.root, filepath.FromSlash(p)))
+	if err != nil {
+		l.errorf(p, "could not open: %v", err)
+		return image.Point{}, false
+	}
+	defer f.Close()
+
+	i, err := png.Decode(f)
+	if err != nil {
+		l.errorf(p, "could not decode: %v", err)
+		return image.Point{}, false
+	}
+
+	return i.Bounds().Size(), true
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package lint_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/myitcv/gopherize.me/artwork/lint"
+)
+
+func TestDir(t *testing.T) {
+	tests := []struct {
+		name string
+		root string
+		want []string
+	}{
+		{
+			// the fixture of package scan has one of each kind of problem
+			name: "problems",
+			root: "../scan/testdata/artwork",
+			want: []string{
+				"010-Body/green_gopher.png: described in meta.json but image does not exist",
+				"020-Eyes/big_eyes.png: layer is (10,10); body layers are (8,8)",
+				"020-Eyes/notes.txt: not a PNG image named [A-Za-z0-9_]+.png",
+				"020-Eyes/wink_thumbnail.png: thumbnail has no full-size image",
+				"030-Extras/coffee_thumbnail.png: thumbnail is (3,3); most thumbnails are (2,2)",
+				"030-Extras/glint.png: option glint also appears in 020-Eyes",
+				"030-Extras/glint.png: warning: no artist or licence in 030-Extras/meta.json",
+			},
+		},
+		{
+			name: "no body",
+			root: "testdata/nobody",
+			want: []string{
+				".: no body category, so layer sizes cannot be checked",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ps, err := lint.Dir(tc.root)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []string
+			for _, p := range ps {
+				got = append(got, p.String())
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("Dir(%v) = %q; want %q", tc.root, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDirMissing(t *testing.T) {
+	if _, err := lint.Dir("testdata/no_such_dir"); err == nil {
+		t.Fatalf("Dir() of a missing directory succeeded")
+	}
+}
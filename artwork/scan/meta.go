@@ -0,0 +1,133 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package scan
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+
+	"github.com/myitcv/gopherize.me/gopher"
+)
+
+// MetaFile is the name of the optional metadata file in a category directory.
+// It is JSON of the form:
+//
+//	{
+//		"artist": "Ashley McNamara",
+//		"licence": "CC-BY-NC-SA-4.0",
+//		"multiSelect": true,
+//		"options": {
+//			"ponzu_cms_costume": {
+//				"name": "Ponzu CMS Costume",
+//				"tags": ["ponzu", "cms"],
+//				"sponsored": true,
+//				"order": 10
+//			},
+//			"blue_gopher": {
+//				"colour": "abc3d6"
+//			}
+//		}
+//	}
+//
+// The top-level artist and licence apply to every option in the category
+// that does not give its own. multiSelect allows more than one option in the
+// category to be chosen at once; they are drawn in increasing order, and
+// options with the same order (by default 0) in ID order. colour is the key
+// colour, as six lower-case hex digits, of the region of an option that is
+// recoloured when a recipe chooses a colour; see gopher.Option.Colour. Every
+// field is optional.
+const MetaFile = "meta.json"
+
+type categoryMeta struct {
+	Artist      string                `json:"artist"`
+	Licence     string                `json:"licence"`
+	MultiSelect bool                  `json:"multiSelect"`
+	Options     map[string]optionMeta `json:"options"`
+}
+
+type optionMeta struct {
+	Name      string   `json:"name"`
+	Tags      []string `json:"tags"`
+	Artist    string   `json:"artist"`
+	Licence   string   `json:"licence"`
+	Sponsored bool     `json:"sponsored"`
+	Order     int      `json:"order"`
+	Colour    string   `json:"colour"`
+}
+
+// applyMeta merges the metadata file of c, if there is one, into its options.
+// Entries for options that do not exist are recorded in r.Unknown.
+func (r *Result) applyMeta(root string, c *gopher.Category) error {
+	p := path.Join(c.Dir, MetaFile)
+
+	b, err := ioutil.ReadFile(filepath.Join(root, filepath.FromSlash(p)))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("could not read %v: %v", p, err)
+	}
+
+	var cm categoryMeta
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(&cm); err != nil {
+		return fmt.Errorf("could not parse %v: %v", p, err)
+	}
+
+	c.Multi = cm.MultiSelect
+
+	// the options are already in ID order
+	sort.SliceStable(c.Options, func(i, j int) bool {
+		return cm.Options[c.Options[i].ID].Order < cm.Options[c.Options[j].ID].Order
+	})
+
+	for _, o := range c.Options {
+		om := cm.Options[o.ID]
+
+		if om.Colour != "" && !gopher.ValidColour(om.Colour) {
+			return fmt.Errorf("invalid colour %q for %v in %v; expected rrggbb in lower-case hex", om.Colour, o.ID, p)
+		}
+
+		if om.Name != "" {
+			o.Name = om.Name
+		}
+
+		o.Tags = om.Tags
+		o.Artist = firstNonEmpty(om.Artist, cm.Artist)
+		o.Licence = firstNonEmpty(om.Licence, cm.Licence)
+		o.Sponsored = om.Sponsored
+		o.Colour = om.Colour
+	}
+
+	var missing []string
+	for id := range cm.Options {
+		if c.Option(id) == nil {
+			missing = append(missing, path.Join(c.Dir, id+imageExt))
+		}
+	}
+	sort.Strings(missing)
+
+	r.Unknown = append(r.Unknown, missing...)
+
+	return nil
+}
+
+func firstNonEmpty(ss ...string) string {
+	for _, s := range ss {
+		if s != "" {
+			return s
+		}
+	}
+
+	return ""
+}
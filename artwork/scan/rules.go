@@ -0,0 +1,145 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package scan
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/myitcv/gopherize.me/gopher"
+)
+
+// RulesFile is the name of the optional file in the artwork root that
+// declares which options can be combined. It is JSON of the form:
+//
+//	{
+//		"rules": [
+//			{
+//				"if": ["hats_and_hair_accessories-stay_puft"],
+//				"excludes": ["shirts"]
+//			},
+//			{
+//				"if": ["hair-red_hair_pink_ears"],
+//				"requires": ["body-pink_gopher"],
+//				"prefers": ["facial_hair-red_beard"]
+//			}
+//		]
+//	}
+//
+// References are of the form category-option, as in an encoded recipe, or
+// just category to refer to any option in the category. Each rule applies to
+// every reference in its "if" list; see gopher.Rule for the meaning of
+// excludes, requires and prefers. Since an option never excludes itself, a
+// group of options that exclude one another can be given as both the "if" and
+// "excludes" lists of one rule.
+const RulesFile = "rules.json"
+
+type rulesFile struct {
+	Rules []struct {
+		If       []string `json:"if"`
+		Excludes []string `json:"excludes"`
+		Requires []string `json:"requires"`
+		Prefers  []string `json:"prefers"`
+	} `json:"rules"`
+}
+
+// rules reads the rules file beneath root, if there is one, checking every
+// reference against m
+func rules(root string, m *gopher.Manifest) ([]*gopher.Rule, error) {
+	b, err := ioutil.ReadFile(filepath.Join(root, RulesFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("could not read %v: %v", RulesFile, err)
+	}
+
+	var rf rulesFile
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(&rf); err != nil {
+		return nil, fmt.Errorf("could not parse %v: %v", RulesFile, err)
+	}
+
+	var res []*gopher.Rule
+
+	for i, r := range rf.Rules {
+		errorf := func(format string, args ...interface{}) error {
+			return fmt.Errorf("%v: rule %v: %v", RulesFile, i+1, fmt.Sprintf(format, args...))
+		}
+
+		if len(r.If) == 0 {
+			return nil, errorf("no if")
+		}
+
+		if len(r.Excludes)+len(r.Requires)+len(r.Prefers) == 0 {
+			return nil, errorf("no excludes, requires or prefers")
+		}
+
+		refs := func(ss []string, needOption bool) ([]gopher.Ref, error) {
+			var res []gopher.Ref
+
+			for _, s := range ss {
+				f, err := gopher.ParseRef(s)
+				if err != nil {
+					return nil, errorf("%v", err)
+				}
+
+				c := m.Category(f.Category)
+				if c == nil {
+					return nil, errorf("unknown category %q", f.Category)
+				}
+
+				if f.Option == "" {
+					if needOption {
+						return nil, errorf("%q must name an option", s)
+					}
+				} else if c.Option(f.Option) == nil {
+					return nil, errorf("unknown option %q in category %q", f.Option, f.Category)
+				}
+
+				res = append(res, f)
+			}
+
+			return res, nil
+		}
+
+		ifs, err := refs(r.If, false)
+		if err != nil {
+			return nil, err
+		}
+
+		excludes, err := refs(r.Excludes, false)
+		if err != nil {
+			return nil, err
+		}
+
+		requires, err := refs(r.Requires, false)
+		if err != nil {
+			return nil, err
+		}
+
+		prefers, err := refs(r.Prefers, true)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, f := range ifs {
+			res = append(res, &gopher.Rule{
+				If:       f,
+				Excludes: excludes,
+				Requires: requires,
+				Prefers:  prefers,
+			})
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,254 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+// Package scan builds an artwork manifest by walking an artwork directory
+package scan
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/myitcv/gopherize.me/gopher"
+)
+
+const (
+	imageExt        = ".png"
+	thumbnailSuffix = "_thumbnail"
+)
+
+var (
+	categoryDir = regexp.MustCompile(`^([0-9]{3})-([A-Za-z0-9_]+)$`)
+	validID     = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+)
+
+// Result is the outcome of scanning an artwork directory
+type Result struct {
+	Manifest *gopher.Manifest
+
+	// Orphans are the slash-separated paths of thumbnails that have no
+	// corresponding full-size image
+	Orphans []string
+
+	// Ignored are the slash-separated paths of files within category
+	// directories that are not PNG images with a valid name
+	Ignored []string
+
+	// Unknown are the slash-separated paths of full-size images that are
+	// described by a category's metadata file but do not exist
+	Unknown []string
+}
+
+// Dir scans the artwork directory root. Directories beneath root that are not
+// of the form NNN-Category are skipped.
+func Dir(root string) (*Result, error) {
+	fis, err := ioutil.ReadDir(root)
+	if err != nil {
+		return nil, fmt.Errorf("could not read artwork dir %v: %v", root, err)
+	}
+
+	res := &Result{
+		Manifest: &gopher.Manifest{},
+	}
+
+	seen := make(map[string]string)
+
+	for _, fi := range fis {
+		if !fi.IsDir() {
+			continue
+		}
+
+		m := categoryDir.FindStringSubmatch(fi.Name())
+		if m == nil {
+			continue
+		}
+
+		order, _ := strconv.Atoi(m[1])
+
+		c := &gopher.Category{
+			ID:    strings.ToLower(m[2]),
+			Name:  strings.Replace(m[2], "_", " ", -1),
+			Dir:   fi.Name(),
+			Order: order,
+		}
+
+		if c.ID == gopher.ColourKey {
+			return nil, fmt.Errorf("category directory %v has the reserved ID %q", c.Dir, c.ID)
+		}
+
+		if other, ok := seen[c.ID]; ok {
+			return nil, fmt.Errorf("category directories %v and %v have the same ID %q", other, c.Dir, c.ID)
+		}
+		seen[c.ID] = c.Dir
+
+		if err := res.scanCategory(root, c); err != nil {
+			return nil, err
+		}
+
+		res.Manifest.Categories = append(res.Manifest.Categories, c)
+	}
+
+	sort.SliceStable(res.Manifest.Categories, func(i, j int) bool {
+		return res.Manifest.Categories[i].Order < res.Manifest.Categories[j].Order
+	})
+
+	v, err := version(root, res.Manifest)
+	if err != nil {
+		return nil, err
+	}
+	res.Manifest.Version = v
+
+	rs, err := rules(root, res.Manifest)
+	if err != nil {
+		return nil, err
+	}
+	res.Manifest.Rules = rs
+
+	return res, nil
+}
+
+// versionLen is the number of hex digits of the digest kept in a manifest
+// version
+const versionLen = 16
+
+// version returns a digest of the path and contents of every full-size image
+// in m, in manifest order, and of every category's metadata file, which
+// changes how options are drawn, e.g. their order and colour
+func version(root string, m *gopher.Manifest) (string, error) {
+	h := sha256.New()
+
+	for _, c := range m.Categories {
+		p := path.Join(c.Dir, MetaFile)
+
+		b, err := ioutil.ReadFile(filepath.Join(root, filepath.FromSlash(p)))
+		switch {
+		case err == nil:
+			fmt.Fprintf(h, "%v\x00", p)
+			h.Write(b)
+		case !os.IsNotExist(err):
+			return "", fmt.Errorf("could not read %v: %v", p, err)
+		}
+
+		for _, o := range c.Options {
+			fmt.Fprintf(h, "%v\x00", o.Image)
+
+			f, err := os.Open(filepath.Join(root, filepath.FromSlash(o.Image)))
+			if err != nil {
+				return "", fmt.Errorf("could not open %v: %v", o.Image, err)
+			}
+
+			_, err = io.Copy(h, f)
+			f.Close()
+			if err != nil {
+				return "", fmt.Errorf("could not read %v: %v", o.Image, err)
+			}
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil))[:versionLen], nil
+}
+
+func (r *Result) scanCategory(root string, c *gopher.Category) error {
+	fis, err := ioutil.ReadDir(filepath.Join(root, c.Dir))
+	if err != nil {
+		return fmt.Errorf("could not read category dir %v: %v", c.Dir, err)
+	}
+
+	images := make(map[string]bool)
+	thumbs := make(map[string]bool)
+
+	for _, fi := range fis {
+		if fi.IsDir() {
+			continue
+		}
+
+		fn := fi.Name()
+		if fn == MetaFile {
+			continue
+		}
+
+		p := path.Join(c.Dir, fn)
+
+		if !strings.HasSuffix(fn, imageExt) {
+			r.Ignored = append(r.Ignored, p)
+			continue
+		}
+
+		id := strings.TrimSuffix(fn, imageExt)
+
+		isThumb := strings.HasSuffix(id, thumbnailSuffix)
+		if isThumb {
+			id = strings.TrimSuffix(id, thumbnailSuffix)
+		}
+
+		if !validID.MatchString(id) {
+			r.Ignored = append(r.Ignored, p)
+			continue
+		}
+
+		if isThumb {
+			thumbs[id] = true
+		} else {
+			images[id] = true
+		}
+	}
+
+	for id := range images {
+		o := &gopher.Option{
+			ID:    id,
+			Name:  DisplayName(id),
+			Image: path.Join(c.Dir, id+imageExt),
+		}
+
+		if thumbs[id] {
+			o.Thumbnail = path.Join(c.Dir, id+thumbnailSuffix+imageExt)
+		}
+
+		c.Options = append(c.Options, o)
+	}
+
+	for id := range thumbs {
+		if !images[id] {
+			r.Orphans = append(r.Orphans, path.Join(c.Dir, id+thumbnailSuffix+imageExt))
+		}
+	}
+
+	sort.Slice(c.Options, func(i, j int) bool {
+		li, lj := strings.ToLower(c.Options[i].ID), strings.ToLower(c.Options[j].ID)
+		if li != lj {
+			return li < lj
+		}
+		return c.Options[i].ID < c.Options[j].ID
+	})
+
+	sort.Strings(r.Orphans)
+	sort.Strings(r.Ignored)
+
+	return r.applyMeta(root, c)
+}
+
+// DisplayName derives a display name from an option ID, e.g. blue_gopher
+// becomes Blue Gopher
+func DisplayName(id string) string {
+	words := strings.FieldsFunc(id, func(r rune) bool {
+		return r == '_'
+	})
+
+	for i, w := range words {
+		r, n := utf8.DecodeRuneInString(w)
+		words[i] = string(unicode.ToUpper(r)) + w[n:]
+	}
+
+	return strings.Join(words, " ")
+}
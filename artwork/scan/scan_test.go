@@ -0,0 +1,220 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package scan_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/myitcv/gopherize.me/artwork/scan"
+	"github.com/myitcv/gopherize.me/gopher"
+)
+
+// fixture is a small artwork directory with one of each kind of problem
+const fixture = "testdata/artwork"
+
+func TestDir(t *testing.T) {
+	res, err := scan.Dir(fixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := res.Manifest
+
+	var ids []string
+	for _, c := range m.Categories {
+		ids = append(ids, c.ID)
+	}
+	if want := []string{"body", "eyes", "extras"}; !reflect.DeepEqual(ids, want) {
+		t.Fatalf("got categories %v; want %v", ids, want)
+	}
+
+	body := m.Category("body")
+	if body.Dir != "010-Body" || body.Name != "Body" || body.Order != 10 || body.Multi {
+		t.Errorf("got body category %+v", body)
+	}
+
+	// options are in order, then ID order
+	want := []*gopher.Option{
+		{
+			ID:        "pink_gopher",
+			Name:      "Pink",
+			Image:     "010-Body/pink_gopher.png",
+			Thumbnail: "010-Body/pink_gopher_thumbnail.png",
+			Artist:    "Renee French",
+			Licence:   "CC-BY-3.0",
+			Colour:    "ffcaca",
+		},
+		{
+			ID:        "blue_gopher",
+			Name:      "Blue Gopher",
+			Image:     "010-Body/blue_gopher.png",
+			Thumbnail: "010-Body/blue_gopher_thumbnail.png",
+			Artist:    "Renee French",
+			Licence:   "CC-BY-3.0",
+			Colour:    "abc3d6",
+		},
+	}
+	if !reflect.DeepEqual(body.Options, want) {
+		t.Errorf("got body options %+v; want %+v", body.Options, want)
+	}
+
+	eyes := m.Category("eyes")
+	ids = nil
+	for _, o := range eyes.Options {
+		ids = append(ids, o.ID)
+	}
+	if want := []string{"big_eyes", "eyes", "glint"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("got eyes options %v; want %v", ids, want)
+	}
+
+	extras := m.Category("extras")
+	if !extras.Multi {
+		t.Errorf("extras is not multi-select")
+	}
+	coffee := extras.Option("coffee")
+	if coffee.Artist != "Ashley McNamara" || !coffee.Sponsored || !reflect.DeepEqual(coffee.Tags, []string{"drink"}) {
+		t.Errorf("got coffee option %+v", coffee)
+	}
+	if glint := extras.Option("glint"); glint.Artist != "" || glint.Licence != "" {
+		t.Errorf("got glint option %+v; want no artist or licence", glint)
+	}
+
+	if want := []string{"020-Eyes/wink_thumbnail.png"}; !reflect.DeepEqual(res.Orphans, want) {
+		t.Errorf("got orphans %v; want %v", res.Orphans, want)
+	}
+	if want := []string{"020-Eyes/notes.txt"}; !reflect.DeepEqual(res.Ignored, want) {
+		t.Errorf("got ignored %v; want %v", res.Ignored, want)
+	}
+	if want := []string{"010-Body/green_gopher.png"}; !reflect.DeepEqual(res.Unknown, want) {
+		t.Errorf("got unknown %v; want %v", res.Unknown, want)
+	}
+
+	if len(m.Rules) != 1 || m.Rules[0].If.String() != "extras-coffee" {
+		t.Errorf("got rules %v; want the one in %v", m.Rules, scan.RulesFile)
+	}
+
+	if len(m.Version) != 16 {
+		t.Errorf("got version %q; want 16 hex digits", m.Version)
+	}
+}
+
+func TestVersion(t *testing.T) {
+	orig, err := scan.Dir(fixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	copyDir(t, fixture, dir)
+
+	res, err := scan.Dir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Manifest.Version != orig.Manifest.Version {
+		t.Fatalf("copy has version %v; want %v", res.Manifest.Version, orig.Manifest.Version)
+	}
+
+	changes := []struct {
+		name string
+		fn   string
+		b    []byte
+	}{
+		{"image", "020-Eyes/glint.png", readFile(t, filepath.Join(fixture, "020-Eyes/eyes.png"))},
+		{"metadata", "010-Body/meta.json", []byte(`{"options": {"blue_gopher": {"colour": "000000"}}}`)},
+	}
+
+	for _, c := range changes {
+		t.Run(c.name, func(t *testing.T) {
+			dir := t.TempDir()
+			copyDir(t, fixture, dir)
+
+			if err := ioutil.WriteFile(filepath.Join(dir, c.fn), c.b, 0666); err != nil {
+				t.Fatal(err)
+			}
+
+			res, err := scan.Dir(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.Manifest.Version == orig.Manifest.Version {
+				t.Fatalf("changing %v left the version %v", c.fn, res.Manifest.Version)
+			}
+		})
+	}
+}
+
+func TestDirErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+	}{
+		{"reserved category", map[string]string{"010-Colour/meta.json": "{}"}},
+		{"duplicate category", map[string]string{"010-Body/meta.json": "{}", "011-body/meta.json": "{}"}},
+		{"unknown meta field", map[string]string{"010-Body/meta.json": `{"artists": "Renee French"}`}},
+		{"bad colour", map[string]string{"010-Body/meta.json": `{"options": {"blue_gopher": {"colour": "ABC3D6"}}}`}},
+		{"unknown rule option", map[string]string{"rules.json": `{"rules": [{"if": ["body-no_such_gopher"]}]}`}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			copyDir(t, fixture, dir)
+
+			for fn, s := range tc.files {
+				p := filepath.Join(dir, filepath.FromSlash(fn))
+				if err := os.MkdirAll(filepath.Dir(p), 0777); err != nil {
+					t.Fatal(err)
+				}
+				if err := ioutil.WriteFile(p, []byte(s), 0666); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if _, err := scan.Dir(dir); err == nil {
+				t.Fatalf("Dir() succeeded; want error")
+			}
+		})
+	}
+}
+
+// copyDir copies the files beneath from to the existing directory to
+func copyDir(t *testing.T, from, to string) {
+	t.Helper()
+
+	err := filepath.Walk(from, func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(from, p)
+		if err != nil {
+			return err
+		}
+
+		if fi.IsDir() {
+			return os.MkdirAll(filepath.Join(to, rel), 0777)
+		}
+
+		return ioutil.WriteFile(filepath.Join(to, rel), readFile(t, p), 0666)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readFile(t *testing.T, fn string) []byte {
+	t.Helper()
+
+	b, err := ioutil.ReadFile(fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return b
+}
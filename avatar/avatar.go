@@ -0,0 +1,152 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+// Package avatar derives a stable gopher from an identity, e.g. an email
+// address or username, in the manner of Gravatar.
+//
+// An identity is first hashed to a key, the hex-encoded MD5 or SHA-256 digest
+// of the trimmed, lower-cased identity, exactly as Gravatar does for email
+// addresses. The gopher for a key is then chosen category by category using
+// rendezvous hashing: every option is scored by hashing the key together with
+// the category and option IDs, and the option with the highest score wins.
+//
+// Because scores depend only on IDs, adding an option to a category changes
+// the avatar of only those keys for which the new option scores highest,
+// about one in n+1 for a category that had n options; everyone else keeps
+// their gopher. Removing an option likewise only affects the keys that chose
+// it. To keep every existing avatar exactly as it is while artwork is added,
+// restrict avatars to a Pool of options and grow the pool deliberately.
+package avatar
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/myitcv/gopherize.me/gopher"
+)
+
+// Pool lists, by category ID, the IDs of the options from which avatars are
+// chosen. A nil Pool allows every option in the manifest; otherwise
+// categories absent from the pool are left empty.
+type Pool map[string][]string
+
+// Key returns the SHA-256 key of identity
+func Key(identity string) string {
+	h := sha256.Sum256([]byte(normalise(identity)))
+	return hex.EncodeToString(h[:])
+}
+
+// MD5Key returns the MD5 key of identity, as used by Gravatar
+func MD5Key(identity string) string {
+	h := md5.Sum([]byte(normalise(identity)))
+	return hex.EncodeToString(h[:])
+}
+
+func normalise(identity string) string {
+	return strings.ToLower(strings.TrimSpace(identity))
+}
+
+// ValidKey reports whether key is a hex-encoded MD5 or SHA-256 digest
+func ValidKey(key string) bool {
+	if len(key) != 2*md5.Size && len(key) != 2*sha256.Size {
+		return false
+	}
+
+	_, err := hex.DecodeString(key)
+	return err == nil
+}
+
+// Recipe returns the gopher for key, chosen from the options of m that are in
+// p. The categories in gopher.Optional are included with the same
+// probability as for gopher.Random. The MD5 and SHA-256 keys of the same
+// identity give different gophers. The gopher breaks none of the rules of m.
+func Recipe(m *gopher.Manifest, p Pool, key string) gopher.Recipe {
+	key = strings.ToLower(key)
+
+	res := make(gopher.Recipe)
+
+	for _, c := range m.Categories {
+		if prob, ok := gopher.Optional[c.ID]; ok && unit(key, "include", c.ID) >= prob {
+			continue
+		}
+
+		var best string
+		var bestScore uint64
+
+		for _, id := range options(c, p) {
+			if s := score(key, c.ID, id); best == "" || s > bestScore {
+				best, bestScore = id, s
+			}
+		}
+
+		if best != "" {
+			res[c.ID] = []string{best}
+		}
+	}
+
+	return res.Resolve(m, gopher.Ref{})
+}
+
+// options returns the IDs of the options of c that are in p
+func options(c *gopher.Category, p Pool) []string {
+	var res []string
+
+	if p == nil {
+		for _, o := range c.Options {
+			res = append(res, o.ID)
+		}
+		return res
+	}
+
+	for _, id := range p[c.ID] {
+		if c.Option(id) != nil {
+			res = append(res, id)
+		}
+	}
+
+	return res
+}
+
+// score hashes key together with parts
+func score(key string, parts ...string) uint64 {
+	h := sha256.New()
+	h.Write([]byte(key))
+	for _, p := range parts {
+		h.Write([]byte{0})
+		h.Write([]byte(p))
+	}
+
+	return binary.BigEndian.Uint64(h.Sum(nil))
+}
+
+// unit maps score(key, parts...) to [0, 1)
+func unit(key string, parts ...string) float64 {
+	return float64(score(key, parts...)>>11) / (1 << 53)
+}
+
+// LoadPool reads a pool from the JSON file fn, an object mapping category IDs
+// to arrays of option IDs
+func LoadPool(fn string) (Pool, error) {
+	b, err := ioutil.ReadFile(fn)
+	if err != nil {
+		return nil, fmt.Errorf("could not read pool: %v", err)
+	}
+
+	var res Pool
+
+	if err := json.Unmarshal(b, &res); err != nil {
+		return nil, fmt.Errorf("could not parse pool %v: %v", fn, err)
+	}
+
+	if res == nil {
+		res = make(Pool)
+	}
+
+	return res, nil
+}
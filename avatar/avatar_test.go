@@ -0,0 +1,154 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package avatar_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/myitcv/gopherize.me/avatar"
+	"github.com/myitcv/gopherize.me/gopher"
+)
+
+// testManifest returns a manifest with no rules, whose categories are not
+// optional, so that every avatar has an option from each
+func testManifest() *gopher.Manifest {
+	opts := func(ids ...string) []*gopher.Option {
+		var res []*gopher.Option
+		for _, id := range ids {
+			res = append(res, &gopher.Option{ID: id})
+		}
+		return res
+	}
+
+	return &gopher.Manifest{
+		Categories: []*gopher.Category{
+			{ID: "body", Options: opts("blue_gopher", "pink_gopher", "green_gopher")},
+			{ID: "eyes", Options: opts("eyes", "eyelashes")},
+			{ID: "shirts", Options: opts("docker_shirt", "go_shirt", "plain_shirt", "striped_shirt")},
+		},
+	}
+}
+
+// testKeys returns n keys of both kinds
+func testKeys(n int) []string {
+	var res []string
+	for i := 0; i < n; i++ {
+		id := fmt.Sprintf("gopher%v@example.com", i)
+		res = append(res, avatar.Key(id), avatar.MD5Key(id))
+	}
+	return res
+}
+
+func TestAddOption(t *testing.T) {
+	before := testManifest()
+	after := testManifest()
+
+	shirts := after.Category("shirts")
+	shirts.Options = append(shirts.Options, &gopher.Option{ID: "new_shirt"})
+
+	changed := 0
+	keys := testKeys(1000)
+
+	for _, k := range keys {
+		old := avatar.Recipe(before, nil, k)
+		got := avatar.Recipe(after, nil, k)
+
+		if got.Has("shirts", "new_shirt") {
+			changed++
+			old["shirts"] = []string{"new_shirt"}
+		}
+
+		if !got.Equals(old) {
+			t.Fatalf("Recipe(%v) changed from %v to %v, not to the new option", k, avatar.Recipe(before, nil, k), got)
+		}
+	}
+
+	// about one in five keys choose the new option
+	if want := len(keys) / 5; changed < want/2 || changed > want*2 {
+		t.Fatalf("%v of %v keys chose the new option; want about %v", changed, len(keys), want)
+	}
+}
+
+func TestAddCategory(t *testing.T) {
+	before := testManifest()
+	after := testManifest()
+
+	after.Categories = append(after.Categories, &gopher.Category{
+		ID:      "hats",
+		Options: []*gopher.Option{{ID: "cap"}, {ID: "top_hat"}},
+	})
+
+	for _, k := range testKeys(1000) {
+		got := avatar.Recipe(after, nil, k)
+		delete(got, "hats")
+
+		if old := avatar.Recipe(before, nil, k); !got.Equals(old) {
+			t.Fatalf("Recipe(%v) changed from %v to %v other than in the new category", k, old, got)
+		}
+	}
+}
+
+func TestRecipePool(t *testing.T) {
+	m := testManifest()
+	p := avatar.Pool{
+		"body":   {"pink_gopher", "no_such_body"},
+		"shirts": {"go_shirt", "plain_shirt"},
+	}
+
+	for _, k := range testKeys(100) {
+		r := avatar.Recipe(m, p, k)
+
+		if err := r.Validate(m); err != nil {
+			t.Fatalf("Recipe(%v) = %v, which is invalid: %v", k, r, err)
+		}
+		if !r.Has("body", "pink_gopher") {
+			t.Fatalf("Recipe(%v) = %v; want body pink_gopher, the only body in the pool", k, r)
+		}
+		if _, ok := r["eyes"]; ok {
+			t.Fatalf("Recipe(%v) = %v; want no eyes, which are not in the pool", k, r)
+		}
+		if !r.Has("shirts", "go_shirt") && !r.Has("shirts", "plain_shirt") {
+			t.Fatalf("Recipe(%v) = %v; want a shirt from the pool", k, r)
+		}
+
+		if again := avatar.Recipe(m, p, k); !again.Equals(r) {
+			t.Fatalf("Recipe(%v) gave %v then %v", k, r, again)
+		}
+	}
+}
+
+func TestKey(t *testing.T) {
+	// the example from Gravatar's documentation
+	const id = " MyEmailAddress@example.com "
+
+	if got, want := avatar.MD5Key(id), "0bc83cb571cd1c50ba6f3e8a78ef1346"; got != want {
+		t.Errorf("MD5Key(%q) = %v; want %v", id, got, want)
+	}
+	if got, want := avatar.Key(id), "84059b07d4be67b806386c0aad8070a23f18836bbaae342275dc0a83414c32ee"; got != want {
+		t.Errorf("Key(%q) = %v; want %v", id, got, want)
+	}
+}
+
+func TestValidKey(t *testing.T) {
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{avatar.MD5Key("gopher@example.com"), true},
+		{avatar.Key("gopher@example.com"), true},
+		{"0BC83CB571CD1C50BA6F3E8A78EF1346", true},
+		{"", false},
+		{"gopher@example.com", false},
+		{"0bc83cb571cd1c50ba6f3e8a78ef134", false},
+		{"0bc83cb571cd1c50ba6f3e8a78ef13466", false},
+		{"0bc83cb571cd1c50ba6f3e8a78ef134g", false},
+	}
+
+	for _, tc := range tests {
+		if got := avatar.ValidKey(tc.key); got != tc.want {
+			t.Errorf("ValidKey(%q) = %v; want %v", tc.key, got, tc.want)
+		}
+	}
+}
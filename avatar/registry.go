@@ -0,0 +1,65 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package avatar
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/myitcv/gopherize.me/gopher"
+)
+
+// Registry maps keys to gophers that have been chosen rather than derived.
+// As with Gravatar, a key that is not registered has no avatar of its own.
+type Registry map[string]gopher.Recipe
+
+// Add registers r as the gopher of identity, under both its MD5 and SHA-256
+// keys
+func (reg Registry) Add(identity string, r gopher.Recipe) {
+	reg[MD5Key(identity)] = r
+	reg[Key(identity)] = r
+}
+
+// Lookup returns the gopher registered for key, if any
+func (reg Registry) Lookup(key string) (gopher.Recipe, bool) {
+	r, ok := reg[key]
+	return r, ok
+}
+
+// LoadRegistry reads a registry from the JSON file fn, an object mapping
+// identities to encoded recipes, e.g.
+//
+//	{"gopher@example.com": "1.body-blue_gopher.eyes-eyes"}
+//
+// Every recipe is validated against m, and made to follow any rules of m added
+// since it was registered.
+func LoadRegistry(fn string, m *gopher.Manifest) (Registry, error) {
+	b, err := ioutil.ReadFile(fn)
+	if err != nil {
+		return nil, fmt.Errorf("could not read registry: %v", err)
+	}
+
+	var entries map[string]string
+
+	if err := json.Unmarshal(b, &entries); err != nil {
+		return nil, fmt.Errorf("could not parse registry %v: %v", fn, err)
+	}
+
+	res := make(Registry)
+
+	for id, enc := range entries {
+		r, err := gopher.Decode(enc)
+		if err == nil {
+			err = r.Validate(m)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid recipe for %v in registry %v: %v", id, fn, err)
+		}
+
+		res.Add(id, r.Resolve(m, gopher.Ref{}))
+	}
+
+	return res, nil
+}
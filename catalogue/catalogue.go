@@ -0,0 +1,280 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+// Package catalogue renders a contact sheet of artwork: every option of every
+// category composited onto a base gopher and labelled with its ID and name.
+// It is intended for finding artwork and for reviewing changes to it.
+package catalogue
+
+import (
+	"fmt"
+	"html/template"
+	"image"
+	"image/color"
+	"image/draw"
+	"io"
+	"sort"
+
+	"github.com/myitcv/gopherize.me/compositor"
+	"github.com/myitcv/gopherize.me/gopher"
+)
+
+const (
+	// DefaultCell is the default size of the gopher in each cell
+	DefaultCell = 160
+
+	// DefaultColumns is the default number of cells in each row
+	DefaultColumns = 8
+
+	padding = 8
+
+	// labelLines is the number of lines of text beneath each gopher: the
+	// option ID and name
+	labelLines  = 2
+	lineSpacing = 4
+
+	headingScale = 2
+)
+
+var (
+	textColour    = color.Gray{Y: 0x33}
+	subtleColour  = color.Gray{Y: 0x88}
+	headingColour = color.Gray{Y: 0xee}
+)
+
+// Options control which options are catalogued and how
+type Options struct {
+	// Base is the gopher onto which each option is composited, replacing
+	// the base's option in the same category. A nil Base is gopher.Default.
+	Base gopher.Recipe
+
+	// Categories restricts the catalogue to the categories with these IDs;
+	// nil catalogues every category
+	Categories []string
+
+	// Cell is the size of the gopher in each cell; zero is DefaultCell
+	Cell int
+
+	// Columns is the number of cells in each row of a sheet; zero is
+	// DefaultColumns
+	Columns int
+
+	// Background fills the background of the sheet and each gopher; nil is
+	// white
+	Background color.Color
+}
+
+func (o Options) withDefaults() Options {
+	if o.Base == nil {
+		o.Base = gopher.Default
+	}
+	if o.Cell == 0 {
+		o.Cell = DefaultCell
+	}
+	if o.Columns == 0 {
+		o.Columns = DefaultColumns
+	}
+	if o.Background == nil {
+		o.Background = color.White
+	}
+	return o
+}
+
+// Entry is a single option in a catalogue
+type Entry struct {
+	Category *gopher.Category
+	Option   *gopher.Option
+
+	// Image is the option composited onto the base gopher, scaled to fit a
+	// square cell
+	Image *image.RGBA
+}
+
+// Entries composites each option selected by opts onto the base gopher, in
+// manifest order
+func Entries(c *compositor.Compositor, opts Options) ([]Entry, error) {
+	opts = opts.withDefaults()
+
+	m := c.Manifest()
+
+	if err := opts.Base.Validate(m); err != nil {
+		return nil, fmt.Errorf("invalid base gopher: %v", err)
+	}
+
+	want := make(map[string]bool)
+	for _, id := range opts.Categories {
+		if m.Category(id) == nil {
+			return nil, fmt.Errorf("unknown category %q", id)
+		}
+		want[id] = true
+	}
+
+	// decode the base once; each entry then only decodes its own option
+	base, err := c.Layers(opts.Base)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []Entry
+
+	for _, cat := range m.Categories {
+		if len(want) > 0 && !want[cat.ID] {
+			continue
+		}
+
+		for _, o := range cat.Options {
+			// the option is shown on the base whatever the artwork's rules
+			ol, err := c.Layer(cat, o)
+			if err != nil {
+				return nil, err
+			}
+
+			ls := []compositor.Layer{ol}
+			for _, l := range base {
+				if l.Category != cat {
+					ls = append(ls, l)
+				}
+			}
+
+			sort.SliceStable(ls, func(i, j int) bool {
+				return ls[i].Category.Order < ls[j].Category.Order
+			})
+
+			img := compositor.Square(compositor.Flatten(ls))
+			img = compositor.Scale(img, opts.Cell, opts.Cell, compositor.CatmullRom)
+
+			res = append(res, Entry{
+				Category: cat,
+				Option:   o,
+				Image:    compositor.Background(img, opts.Background),
+			})
+		}
+	}
+
+	return res, nil
+}
+
+// Sheet lays es out as a grid, in rows of opts.Columns cells, with a heading
+// for each category
+func Sheet(es []Entry, opts Options) *image.RGBA {
+	opts = opts.withDefaults()
+
+	cellW := opts.Cell + 2*padding
+	labelH := labelLines*glyphH + (labelLines-1)*lineSpacing
+	cellH := opts.Cell + 3*padding + labelH
+	headingH := glyphH*headingScale + 2*padding
+
+	// group the entries by category, preserving their order
+	var groups [][]Entry
+	for i, e := range es {
+		if i == 0 || e.Category != es[i-1].Category {
+			groups = append(groups, nil)
+		}
+		groups[len(groups)-1] = append(groups[len(groups)-1], e)
+	}
+
+	height := 0
+	for _, g := range groups {
+		rows := (len(g) + opts.Columns - 1) / opts.Columns
+		height += headingH + rows*cellH
+	}
+
+	res := image.NewRGBA(image.Rect(0, 0, opts.Columns*cellW, height))
+	draw.Draw(res, res.Bounds(), image.NewUniform(opts.Background), image.Point{}, draw.Src)
+
+	y := 0
+
+	for _, g := range groups {
+		cat := g[0].Category
+
+		hr := image.Rect(0, y, res.Bounds().Dx(), y+headingH)
+		draw.Draw(res, hr, image.NewUniform(headingColour), image.Point{}, draw.Src)
+
+		heading := fmt.Sprintf("%v (%v, %v options)", cat.Name, cat.Dir, len(cat.Options))
+		drawText(res, image.Pt(padding, y+padding), fitText(heading, hr.Dx()-2*padding, headingScale), textColour, headingScale)
+
+		y += headingH
+
+		for i, e := range g {
+			x := (i % opts.Columns) * cellW
+			cy := y + (i/opts.Columns)*cellH
+
+			r := image.Rect(0, 0, opts.Cell, opts.Cell).Add(image.Pt(x+padding, cy+padding))
+			draw.Draw(res, r, e.Image, image.Point{}, draw.Over)
+
+			ly := r.Max.Y + padding
+			drawText(res, image.Pt(x+padding, ly), fitText(e.Option.ID, opts.Cell, 1), textColour, 1)
+			drawText(res, image.Pt(x+padding, ly+glyphH+lineSpacing), fitText(e.Option.Name, opts.Cell, 1), subtleColour, 1)
+		}
+
+		y += (len(g) + opts.Columns - 1) / opts.Columns * cellH
+	}
+
+	return res
+}
+
+var htmlTmpl = template.Must(template.New("catalogue").Parse(`<!doctype html>
+<html lang="en">
+  <head>
+    <meta charset="utf-8">
+    <title>gopherize.me artwork catalogue</title>
+    <style>
+      body { font-family: sans-serif; }
+      figure { display: inline-block; margin: 8px; width: {{.Cell}}px; vertical-align: top; }
+      figure img { width: {{.Cell}}px; height: {{.Cell}}px; }
+      figcaption code, figcaption small { display: block; word-break: break-all; }
+    </style>
+  </head>
+  <body>
+    <h1>gopherize.me artwork catalogue</h1>
+    {{- range .Groups}}
+    <h2 id="{{.Category.ID}}">{{.Category.Name}} <small>{{.Category.Dir}}</small></h2>
+    {{- range .Entries}}
+    <figure>
+      <img src="{{.Src}}" alt="{{.Option.Name}}">
+      <figcaption>
+        <code>{{.Option.ID}}</code>{{.Option.Name}}
+        {{- with .Option.Artist}}<small>by {{.}}</small>{{end}}
+        {{- with .Option.Licence}}<small>{{.}}</small>{{end}}
+        {{- if .Option.Sponsored}}<small>sponsored</small>{{end}}
+        {{- with .Option.Tags}}<small>{{range $i, $t := .}}{{if $i}}, {{end}}{{$t}}{{end}}</small>{{end}}
+      </figcaption>
+    </figure>
+    {{- end}}
+    {{- end}}
+  </body>
+</html>
+`))
+
+// HTML writes to w an HTML page listing es by category. src returns the URL
+// of the image of each entry, which the caller is responsible for writing.
+func HTML(w io.Writer, es []Entry, opts Options, src func(Entry) string) error {
+	opts = opts.withDefaults()
+
+	type entry struct {
+		Entry
+		Src string
+	}
+
+	type group struct {
+		Category *gopher.Category
+		Entries  []entry
+	}
+
+	var groups []*group
+	for _, e := range es {
+		if len(groups) == 0 || groups[len(groups)-1].Category != e.Category {
+			groups = append(groups, &group{Category: e.Category})
+		}
+		g := groups[len(groups)-1]
+		g.Entries = append(g.Entries, entry{Entry: e, Src: src(e)})
+	}
+
+	return htmlTmpl.Execute(w, struct {
+		Cell   int
+		Groups []*group
+	}{
+		Cell:   opts.Cell,
+		Groups: groups,
+	})
+}
@@ -8,20 +8,75 @@ package main
 import (
 	r "myitcv.io/react"
 
-	"fmt"
+	"time"
+
+	"github.com/myitcv/gopherize.me/artwork"
+	"github.com/myitcv/gopherize.me/gopher"
 )
 
+// artworkBase is the URL, relative to the page, beneath which the artwork is
+// found. By default the artwork is expected alongside the app; see
+// loadArtworkBase for how to point elsewhere.
+var artworkBase = "artwork/"
+
+// defaultRecipe is the gopher the app starts with and returns to on reset
+var defaultRecipe = gopher.Default
+
 type appDef struct {
 	r.ComponentDef
 }
 
+// appState is the state type for the app component
+type appState struct {
+	// selection is the option selected in each category
+	selection gopher.Recipe
+
+	// tinted are the data URLs of layer images recoloured for the preview, by
+	// tintKey. Entries are only ever added, never changed.
+	tinted map[string]string
+}
+
 func app() *appDef {
 	res := &appDef{}
 	r.BlessElement(res, nil)
 	return res
 }
 
-func (a appDef) Render() r.Element {
+// Equals must be defined because struct val instances of appState cannot be
+// compared
+func (s appState) Equals(v appState) bool {
+	if !s.selection.Equals(v.selection) || len(s.tinted) != len(v.tinted) {
+		return false
+	}
+
+	for k := range s.tinted {
+		if _, ok := v.tinted[k]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// setSelection selects rec, starting to recolour its layers for the preview
+// if it chooses a colour
+func (a *appDef) setSelection(rec gopher.Recipe) {
+	ns := a.State()
+	ns.selection = rec
+	a.SetState(ns)
+
+	a.tintLayers(rec)
+}
+
+// GetInitialState returns the state of the app before any selection is made
+func (a *appDef) GetInitialState() appState {
+	return appState{
+		selection: defaultRecipe.Clone(),
+	}
+}
+
+// Render renders the app component
+func (a *appDef) Render() r.Element {
 	return r.Div(
 		&r.DivProps{ClassName: "container mt-1"},
 		r.Div(
@@ -30,32 +85,69 @@ func (a appDef) Render() r.Element {
 				&r.DivProps{ClassName: "col-xs-8"},
 				r.Div(
 					&r.DivProps{ClassName: "preview"},
-					r.Img(
-						&r.ImgProps{
-							ClassName: "img-responsive",
-							Src:       "https://storage.googleapis.com/gopherizeme.appspot.com/artwork/010-Body/blue_gopher.png",
-						},
-					),
+					a.renderLayers()...,
 				),
 			),
 			r.Div(
 				&r.DivProps{ClassName: "col-xs-4"},
 				r.Button(
-					&r.ButtonProps{ClassName: "btn btn-default", OnClick: buttonHandler{}},
+					&r.ButtonProps{ClassName: "btn btn-default", OnClick: shuffle{a}},
 					r.S("Shuffle"),
 				),
 				r.Button(
-					&r.ButtonProps{ClassName: "btn btn-default", OnClick: buttonHandler{}},
+					&r.ButtonProps{ClassName: "btn btn-default", OnClick: reset{a}},
 					r.S("Reset"),
 				),
+				picker(pickerProps{app: a, selection: a.State().selection}),
 			),
 		),
 	)
 }
 
-type buttonHandler struct{}
+// renderLayers renders one absolutely positioned image per selected option,
+// in category order so that later categories are drawn on top. Layers to be
+// recoloured are shown in their original colour until they have been
+// recoloured; see setSelection.
+func (a *appDef) renderLayers() []r.Element {
+	var res []r.Element
 
-func (h buttonHandler) OnClick(e *r.SyntheticMouseEvent) {
+	st := a.State()
+	hex := st.selection.Colour()
+
+	for _, l := range st.selection.Layers(artwork.Default) {
+		src := artworkBase + l.Option.Image
+
+		if hex != "" && l.Option.Colour != "" {
+			if u, ok := st.tinted[tintKey(l, hex)]; ok {
+				src = u
+			}
+		}
+
+		res = append(res, r.Img(
+			&r.ImgProps{
+				Key:       l.ID(),
+				ClassName: "layer",
+				Src:       src,
+				Alt:       l.Option.Name,
+			},
+		))
+	}
+
+	return res
+}
+
+type shuffle struct{ a *appDef }
+
+func (s shuffle) OnClick(e *r.SyntheticMouseEvent) {
 	e.PreventDefault()
-	fmt.Println("button clicked", e)
+
+	s.a.setSelection(gopher.Random(artwork.Default, time.Now().UnixNano()))
+}
+
+type reset struct{ a *appDef }
+
+func (rs reset) OnClick(e *r.SyntheticMouseEvent) {
+	e.PreventDefault()
+
+	rs.a.setSelection(defaultRecipe.Clone())
 }
@@ -0,0 +1,76 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/gopherjs/gopherjs/js"
+	"honnef.co/go/js/dom"
+
+	"github.com/myitcv/gopherize.me/artwork"
+	"github.com/myitcv/gopherize.me/gopher"
+	"github.com/myitcv/gopherize.me/pngmeta"
+)
+
+// ComponentDidMount lets a gopher PNG previously rendered by gopherize be
+// dropped anywhere on the page to restore its recipe, and recolours the
+// initial gopher if it chooses a colour
+func (a *appDef) ComponentDidMount() {
+	a.tintLayers(a.State().selection)
+
+	w := dom.GetWindow()
+
+	// the browser only allows a drop if dragover is cancelled
+	w.AddEventListener("dragover", false, func(e dom.Event) {
+		e.PreventDefault()
+	})
+
+	w.AddEventListener("drop", false, func(e dom.Event) {
+		e.PreventDefault()
+		a.drop(e)
+	})
+}
+
+// drop reads the first file dropped by e and, if it is a gopher PNG, selects
+// the recipe embedded in it
+func (a *appDef) drop(e dom.Event) {
+	files := e.Underlying().Get("dataTransfer").Get("files")
+	if files == js.Undefined || files.Length() == 0 {
+		return
+	}
+
+	f := files.Index(0)
+	name := f.Get("name").String()
+
+	fr := js.Global.Get("FileReader").New()
+	fr.Set("onload", func() {
+		b := js.Global.Get("Uint8Array").New(fr.Get("result")).Interface().([]byte)
+
+		rec, err := dropRecipe(b)
+		if err != nil {
+			dom.GetWindow().Alert(fmt.Sprintf("Could not load a gopher from %v: %v", name, err))
+			return
+		}
+
+		a.setSelection(rec)
+	})
+	fr.Call("readAsArrayBuffer", f)
+}
+
+// dropRecipe returns the valid recipe embedded in the PNG image b, changed to
+// follow any rules added since the image was rendered
+func dropRecipe(b []byte) (gopher.Recipe, error) {
+	rec, _, err := pngmeta.ReadRecipe(bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := rec.Validate(artwork.Default); err != nil {
+		return nil, err
+	}
+
+	return rec.Resolve(artwork.Default, gopher.Ref{}), nil
+}
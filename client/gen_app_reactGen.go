@@ -2,8 +2,40 @@
 
 package main
 
+import "myitcv.io/react"
+
 func (a *appDef) ShouldComponentUpdateIntf(nextProps, prevState, nextState interface{}) bool {
 	res := false
 
+	v := prevState.(appState)
+	res = !v.EqualsIntf(nextState) || res
 	return res
 }
+
+// SetState is an auto-generated proxy proxy to update the state for the
+// app component.  SetState does not immediately mutate a.State()
+// but creates a pending state transition.
+func (a *appDef) SetState(state appState) {
+	a.ComponentDef.SetState(state)
+}
+
+// State is an auto-generated proxy to return the current state in use for the
+// render of the app component
+func (a *appDef) State() appState {
+	return a.ComponentDef.State().(appState)
+}
+
+// IsState is an auto-generated definition so that appState implements
+// the myitcv.io/react.State interface.
+func (a appState) IsState() {}
+
+var _ react.State = appState{}
+
+// GetInitialStateIntf is an auto-generated proxy to GetInitialState
+func (a *appDef) GetInitialStateIntf() react.State {
+	return a.GetInitialState()
+}
+
+func (a appState) EqualsIntf(val interface{}) bool {
+	return a.Equals(val.(appState))
+}
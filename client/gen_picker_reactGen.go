@@ -0,0 +1,56 @@
+// Code generated by reactGen. DO NOT EDIT.
+
+package main
+
+import "myitcv.io/react"
+
+func (p *pickerDef) ShouldComponentUpdateIntf(nextProps, prevState, nextState interface{}) bool {
+	res := false
+
+	{
+		res = !p.Props().Equals(nextProps.(pickerProps)) || res
+	}
+	v := prevState.(pickerState)
+	res = !v.EqualsIntf(nextState) || res
+	return res
+}
+
+// SetState is an auto-generated proxy proxy to update the state for the
+// picker component.  SetState does not immediately mutate p.State()
+// but creates a pending state transition.
+func (p *pickerDef) SetState(state pickerState) {
+	p.ComponentDef.SetState(state)
+}
+
+// State is an auto-generated proxy to return the current state in use for the
+// render of the picker component
+func (p *pickerDef) State() pickerState {
+	return p.ComponentDef.State().(pickerState)
+}
+
+// IsState is an auto-generated definition so that pickerState implements
+// the myitcv.io/react.State interface.
+func (p pickerState) IsState() {}
+
+var _ react.State = pickerState{}
+
+// GetInitialStateIntf is an auto-generated proxy to GetInitialState
+func (p *pickerDef) GetInitialStateIntf() react.State {
+	return pickerState{}
+}
+
+func (p pickerState) EqualsIntf(val interface{}) bool {
+	return p == val.(pickerState)
+}
+
+// Props is an auto-generated proxy to the current props of picker
+func (p *pickerDef) Props() pickerProps {
+	uprops := p.ComponentDef.Props()
+	return uprops.(pickerProps)
+}
+
+func (p pickerProps) EqualsIntf(val interface{}) bool {
+	return p.Equals(val.(pickerProps))
+}
+
+var _ react.Equals = pickerProps{}
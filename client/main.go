@@ -1,12 +1,33 @@
 // Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
 // Use of this document is governed by a license found in the LICENSE document.
 
+// The favicon is the default gopher; regenerate it if gopher.Default changes
+//go:generate gopherize --artwork ../artwork export --recipe 1.body-blue_gopher.eyes-eyes -o inc/favicon.ico
+
 package main
 
 import (
+	"fmt"
+	"net/url"
+	"strings"
+
 	r "myitcv.io/react"
 
 	"honnef.co/go/js/dom"
+
+	"github.com/myitcv/gopherize.me/artwork"
+	"github.com/myitcv/gopherize.me/gopher"
+)
+
+const (
+	// defaultParam is the URL query parameter that overrides the default
+	// gopher, e.g. ?default=1.body-pink_gopher.eyes-eyelashes
+	defaultParam = "default"
+
+	// artworkBaseMeta is the name of the meta element whose content, if set,
+	// overrides artworkBase, e.g.
+	// <meta name="artwork-base" content="https://example.com/artwork/">
+	artworkBaseMeta = "artwork-base"
 )
 
 var document = dom.GetWindow().Document()
@@ -14,6 +35,49 @@ var document = dom.GetWindow().Document()
 func main() {
 	domTarget := document.GetElementByID("gopherize.me")
 
-	a := app()
-	r.Render(a.Render(), domTarget)
+	artworkBase = loadArtworkBase(artworkBase)
+	defaultRecipe = loadDefault(dom.GetWindow().Location().Search)
+
+	r.Render(app(), domTarget)
+}
+
+// loadDefault returns the default gopher named by the query string q, falling
+// back to gopher.Default if there is none or it is invalid. A default that
+// breaks rules added since it was shared is changed to follow them.
+func loadDefault(q string) gopher.Recipe {
+	vs, err := url.ParseQuery(q)
+	if err != nil || vs.Get(defaultParam) == "" {
+		return gopher.Default
+	}
+
+	rec, err := gopher.Decode(vs.Get(defaultParam))
+	if err == nil {
+		err = rec.Validate(artwork.Default)
+	}
+	if err != nil {
+		fmt.Printf("ignoring %v parameter: %v\n", defaultParam, err)
+		return gopher.Default
+	}
+
+	return rec.Resolve(artwork.Default, gopher.Ref{})
+}
+
+// loadArtworkBase returns the artwork base URL configured by the page's
+// artworkBaseMeta meta element, falling back to def if there is none
+func loadArtworkBase(def string) string {
+	m := document.QuerySelector(`meta[name="` + artworkBaseMeta + `"]`)
+	if m == nil {
+		return def
+	}
+
+	v := strings.TrimSpace(m.GetAttribute("content"))
+	if v == "" {
+		return def
+	}
+
+	if !strings.HasSuffix(v, "/") {
+		v += "/"
+	}
+
+	return v
 }
@@ -0,0 +1,329 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package main
+
+import (
+	"strings"
+
+	r "myitcv.io/react"
+
+	"honnef.co/go/js/dom"
+
+	"github.com/myitcv/gopherize.me/artwork"
+	"github.com/myitcv/gopherize.me/gopher"
+)
+
+// pickerDef is the definition of the picker component, which lists each
+// category of artwork as a collapsible panel of thumbnails, after a panel
+// choosing the colour of the gopher
+type pickerDef struct {
+	r.ComponentDef
+}
+
+// pickerProps is the props type for the picker component
+type pickerProps struct {
+	// app is the app whose selection the picker changes
+	app *appDef
+
+	selection gopher.Recipe
+}
+
+// pickerState is the state type for the picker component
+type pickerState struct {
+	// open is the ID of the category whose panel is expanded, if any, or
+	// gopher.ColourKey if the colour panel is
+	open string
+}
+
+func picker(p pickerProps) *pickerDef {
+	res := &pickerDef{}
+	r.BlessElement(res, p)
+	return res
+}
+
+// Equals must be defined because struct val instances of pickerProps cannot be
+// compared
+func (p pickerProps) Equals(v pickerProps) bool {
+	return p.app == v.app && p.selection.Equals(v.selection)
+}
+
+// Render renders the picker component
+func (p *pickerDef) Render() r.Element {
+	panels := []r.Element{p.renderColour()}
+
+	for _, c := range artwork.Default.Categories {
+		panels = append(panels, p.renderCategory(c))
+	}
+
+	return r.Div(
+		&r.DivProps{ClassName: "panel-group picker"},
+		panels...,
+	)
+}
+
+func (p *pickerDef) renderCategory(c *gopher.Category) r.Element {
+	open := p.State().open == c.ID
+	rec := p.Props().selection
+
+	var names []string
+	for _, o := range c.Options {
+		if rec.Has(c.ID, o.ID) {
+			names = append(names, o.Name)
+		}
+	}
+
+	title := c.Name
+	if len(names) > 0 {
+		title += ": " + strings.Join(names, ", ")
+	}
+
+	heading := r.Div(
+		&r.DivProps{ClassName: "panel-heading"},
+		r.A(
+			&r.AProps{ClassName: "panel-title", Href: "#", OnClick: panelToggle{p, c.ID}},
+			r.S(title),
+		),
+	)
+
+	if !open {
+		return r.Div(&r.DivProps{ClassName: "panel panel-default"}, heading)
+	}
+
+	none := "tile"
+	if len(names) == 0 {
+		none += " active"
+	}
+
+	tiles := []r.Element{
+		p.renderTile(c.ID, "", "None", artwork.NoneThumbnail, none),
+	}
+
+	for _, o := range c.Options {
+		thumb := o.Thumbnail
+		if thumb == "" {
+			thumb = o.Image
+		}
+
+		// excluded options can still be chosen; choosing one removes the
+		// options it conflicts with
+		cn := "tile"
+		switch {
+		case rec.Has(c.ID, o.ID):
+			cn += " active"
+		case artwork.Default.Excluded(rec, c.ID, o.ID):
+			cn += " excluded"
+		case artwork.Default.Preferred(rec, c.ID, o.ID):
+			cn += " preferred"
+		}
+
+		tiles = append(tiles, p.renderTile(c.ID, o.ID, o.Name, thumb, cn))
+	}
+
+	return r.Div(
+		&r.DivProps{ClassName: "panel panel-default"},
+		heading,
+		r.Div(
+			&r.DivProps{ClassName: "panel-body"},
+			tiles...,
+		),
+	)
+}
+
+// renderColour renders the panel choosing the colour of the gopher: the
+// colours in which the artwork is drawn as presets, and any other colour
+func (p *pickerDef) renderColour() r.Element {
+	open := p.State().open == gopher.ColourKey
+	hex := p.Props().selection.Colour()
+
+	title := "Colour"
+	if hex != "" {
+		title += ": #" + hex
+		for _, o := range artwork.Default.Swatches() {
+			if o.Colour == hex {
+				title = "Colour: " + o.Name
+				break
+			}
+		}
+	}
+
+	heading := r.Div(
+		&r.DivProps{ClassName: "panel-heading"},
+		r.A(
+			&r.AProps{ClassName: "panel-title", Href: "#", OnClick: panelToggle{p, gopher.ColourKey}},
+			r.S(title),
+		),
+	)
+
+	if !open {
+		return r.Div(&r.DivProps{ClassName: "panel panel-default"}, heading)
+	}
+
+	none := "tile"
+	if hex == "" {
+		none += " active"
+	}
+
+	tiles := []r.Element{
+		p.renderSwatch("", "As drawn", artwork.NoneThumbnail, none),
+	}
+
+	for _, o := range artwork.Default.Swatches() {
+		thumb := o.Thumbnail
+		if thumb == "" {
+			thumb = o.Image
+		}
+
+		cn := "tile"
+		if o.Colour == hex {
+			cn += " active"
+		}
+
+		tiles = append(tiles, p.renderSwatch(o.Colour, o.Name, thumb, cn))
+	}
+
+	// the colour input needs a value; start from the colour in which the
+	// gopher is drawn
+	value := hex
+	if value == "" {
+		value = drawnColour(p.Props().selection)
+	}
+
+	tiles = append(tiles, r.Input(
+		&r.InputProps{
+			Key:       "custom",
+			ClassName: "colour",
+			Type:      "color",
+			Value:     "#" + value,
+			OnChange:  colourChange{p},
+		},
+	))
+
+	return r.Div(
+		&r.DivProps{ClassName: "panel panel-default"},
+		heading,
+		r.Div(
+			&r.DivProps{ClassName: "panel-body"},
+			tiles...,
+		),
+	)
+}
+
+// drawnColour returns the colour in which the recolourable parts of the gopher
+// of rec are drawn, or black if it has none
+func drawnColour(rec gopher.Recipe) string {
+	for _, l := range rec.Layers(artwork.Default) {
+		if l.Option.Colour != "" {
+			return l.Option.Colour
+		}
+	}
+
+	return "000000"
+}
+
+func (p *pickerDef) renderSwatch(hex, name, thumb, cn string) r.Element {
+	return r.A(
+		&r.AProps{
+			Key:       hex,
+			ClassName: cn,
+			Href:      "#",
+			OnClick:   swatchClick{p, hex},
+		},
+		r.Img(
+			&r.ImgProps{
+				Src: artworkBase + thumb,
+				Alt: name,
+			},
+		),
+	)
+}
+
+func (p *pickerDef) renderTile(cat, opt, name, thumb, cn string) r.Element {
+	return r.A(
+		&r.AProps{
+			Key:       opt,
+			ClassName: cn,
+			Href:      "#",
+			OnClick:   tileClick{p, cat, opt},
+		},
+		r.Img(
+			&r.ImgProps{
+				Src: artworkBase + thumb,
+				Alt: name,
+			},
+		),
+	)
+}
+
+type panelToggle struct {
+	p   *pickerDef
+	cat string
+}
+
+func (t panelToggle) OnClick(e *r.SyntheticMouseEvent) {
+	e.PreventDefault()
+
+	ns := t.p.State()
+	if ns.open == t.cat {
+		ns.open = ""
+	} else {
+		ns.open = t.cat
+	}
+
+	t.p.SetState(ns)
+}
+
+type tileClick struct {
+	p   *pickerDef
+	cat string
+	opt string
+}
+
+// OnClick selects the tile's option, or deselects it if it is already
+// selected. In a multi-select category other selected options stay selected.
+// The none tile deselects every option in the category. Other options are
+// then changed as needed to follow the artwork's rules.
+func (t tileClick) OnClick(e *r.SyntheticMouseEvent) {
+	e.PreventDefault()
+
+	a := t.p.Props().app
+
+	sel := a.State().selection
+	a.setSelection(sel.Toggle(artwork.Default, t.cat, t.opt).Resolve(artwork.Default, gopher.Ref{Category: t.cat, Option: t.opt}))
+}
+
+type swatchClick struct {
+	p   *pickerDef
+	hex string
+}
+
+// OnClick recolours the gopher to the swatch's colour, or back to the colours
+// in which it is drawn if the swatch has none
+func (s swatchClick) OnClick(e *r.SyntheticMouseEvent) {
+	e.PreventDefault()
+
+	s.p.setColour(s.hex)
+}
+
+type colourChange struct{ p *pickerDef }
+
+// OnChange recolours the gopher to the colour chosen in the colour input
+func (c colourChange) OnChange(e *r.SyntheticEvent) {
+	v := e.Target().(*dom.HTMLInputElement).Value
+
+	hex := strings.ToLower(strings.TrimPrefix(v, "#"))
+	if !gopher.ValidColour(hex) {
+		return
+	}
+
+	c.p.setColour(hex)
+}
+
+// setColour recolours the gopher to hex, or back to the colours in which it is
+// drawn if hex is empty. Without a colour, options may again be needed to
+// match the colours of others, e.g. a body to match the ears of some hair.
+func (p *pickerDef) setColour(hex string) {
+	a := p.Props().app
+
+	a.setSelection(a.State().selection.WithColour(hex).Resolve(artwork.Default, gopher.Ref{}))
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/gopherjs/gopherjs/js"
+	"honnef.co/go/js/dom"
+
+	"github.com/myitcv/gopherize.me/artwork"
+	"github.com/myitcv/gopherize.me/gopher"
+	"github.com/myitcv/gopherize.me/tint"
+)
+
+// tintWidth is the width, in pixels, at which layers are recoloured for the
+// preview: half that of the artwork, since recolouring the full-size artwork
+// takes too long to follow the colour input
+const tintWidth = 650
+
+// tintPending records the keys of layers being, or that have been, recoloured
+// so that each is recoloured at most once
+var tintPending = make(map[string]bool)
+
+// tintKey identifies the image of l recoloured to the colour hex
+func tintKey(l gopher.Layer, hex string) string {
+	return l.ID() + "." + hex
+}
+
+// tintLayers starts recolouring, for the preview, each layer of rec that is
+// recoloured by the colour rec chooses and has not been already
+func (a *appDef) tintLayers(rec gopher.Recipe) {
+	hex := rec.Colour()
+	if hex == "" {
+		return
+	}
+
+	tinted := a.State().tinted
+
+	for _, l := range rec.Layers(artwork.Default) {
+		if l.Option.Colour == "" {
+			continue
+		}
+		if _, ok := tinted[tintKey(l, hex)]; !ok {
+			a.tint(l, hex)
+		}
+	}
+}
+
+// tint recolours the image of l to the colour hex in a canvas, adding the
+// result to the app's tinted images as a data URL. Results for a colour that
+// is no longer selected by the time they are ready are dropped.
+func (a *appDef) tint(l gopher.Layer, hex string) {
+	k := tintKey(l, hex)
+	if tintPending[k] {
+		return
+	}
+	tintPending[k] = true
+
+	from, err := tint.ParseHex(l.Option.Colour)
+	if err != nil {
+		fmt.Printf("could not recolour %v: %v\n", l.ID(), err)
+		return
+	}
+	to, err := tint.ParseHex(hex)
+	if err != nil {
+		fmt.Printf("could not recolour %v: %v\n", l.ID(), err)
+		return
+	}
+
+	img := js.Global.Get("Image").New()
+
+	// the artwork might be served from elsewhere; without this the canvas
+	// would be tainted and its pixels could not be read
+	img.Set("crossOrigin", "anonymous")
+
+	img.Set("onerror", func() {
+		fmt.Printf("could not load %v to recolour it\n", l.Option.Image)
+	})
+
+	img.Set("onload", func() {
+		// the colour input fires for every colour it passes through; only
+		// recolour for the colour still selected
+		if a.State().selection.Colour() != hex {
+			delete(tintPending, k)
+			return
+		}
+
+		w, h := img.Get("naturalWidth").Int(), img.Get("naturalHeight").Int()
+		if w > tintWidth {
+			w, h = tintWidth, h*tintWidth/w
+		}
+
+		c := document.CreateElement("canvas").(*dom.HTMLCanvasElement)
+		c.Width, c.Height = w, h
+
+		ctx := c.GetContext2d()
+		ctx.Call("drawImage", img, 0, 0, w, h)
+
+		// the pixels of image data are not premultiplied, as tint.Pix expects;
+		// they are set back explicitly in case pix is a copy
+		data := ctx.Call("getImageData", 0, 0, w, h)
+		pix := js.Global.Get("Uint8Array").New(data.Get("data").Get("buffer")).Interface().([]byte)
+		tint.Pix(pix, from, to)
+		data.Get("data").Call("set", pix)
+		ctx.Call("putImageData", data, 0, 0)
+
+		ns := a.State()
+		tinted := make(map[string]string, len(ns.tinted)+1)
+		for k, v := range ns.tinted {
+			tinted[k] = v
+		}
+		tinted[k] = c.Call("toDataURL").String()
+
+		ns.tinted = tinted
+		a.SetState(ns)
+	})
+
+	img.Set("src", artworkBase+l.Option.Image)
+}
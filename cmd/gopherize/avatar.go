@@ -0,0 +1,73 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/myitcv/gopherize.me/avatar"
+	"github.com/myitcv/gopherize.me/compositor"
+)
+
+func avatarCmd() *cobra.Command {
+	var (
+		out  string
+		pool string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "avatar identity [-o out.png]",
+		Short: "derive the avatar gopher of an identity",
+		Long: `avatar prints the recipe of the gopher derived from identity, e.g. an email
+address or username, as served by /avatar/ for its SHA-256 key. With -o the
+gopher is also rendered, square, to a PNG image.`,
+	}
+
+	cmd.Flags().StringVarP(&out, "output", "o", "", "the file to which to write the PNG image")
+	cmd.Flags().StringVar(&pool, "avatar-pool", "", "a JSON file restricting the options from which avatars are derived (default every option)")
+	opts := addRenderFlags(cmd)
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return fmt.Errorf("expected exactly one identity; got %v", len(args))
+		}
+
+		o, err := opts()
+		if err != nil {
+			return err
+		}
+		o.Square = true
+
+		m, err := loadArtwork()
+		if err != nil {
+			return err
+		}
+
+		var p avatar.Pool
+		if pool != "" {
+			if p, err = avatar.LoadPool(pool); err != nil {
+				return err
+			}
+		}
+
+		rec := avatar.Recipe(m, p, avatar.Key(args[0]))
+
+		fmt.Println(rec)
+
+		if out == "" {
+			return nil
+		}
+
+		img, err := compositor.New(fArtwork, m).Render(rec, o)
+		if err != nil {
+			return err
+		}
+
+		return writePNG(out, img, rec, m)
+	}
+
+	return cmd
+}
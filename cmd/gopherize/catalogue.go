@@ -0,0 +1,165 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/myitcv/gopherize.me/catalogue"
+	"github.com/myitcv/gopherize.me/compositor"
+	"github.com/myitcv/gopherize.me/gopher"
+)
+
+// maxCatalogueCell is the largest --cell accepted by catalogue
+const maxCatalogueCell = 512
+
+func catalogueCmd() *cobra.Command {
+	var (
+		out        string
+		html       string
+		base       string
+		categories []string
+		cell       int
+		columns    int
+		bg         string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "catalogue [-o sheet.png] [--html dir]",
+		Short: "render a contact sheet of every artwork option",
+		Long: `catalogue composites every option of every category onto a base gopher,
+the default gopher unless --base is given, and lays them out in a grid
+labelled with each option's ID and name.
+
+With -o the grid is written as a PNG image. With --html an index.html page
+listing the options is written to dir, along with an image of each.`,
+		Example: "  " + gopherizeCmd + " catalogue --category extras,glasses -o review.png",
+	}
+
+	cmd.Flags().StringVarP(&out, "output", "o", "", "the file to which to write the contact sheet")
+	cmd.Flags().StringVar(&html, "html", "", "the directory to which to write an HTML catalogue")
+	cmd.Flags().StringVar(&base, "base", "", "the recipe of the gopher onto which each option is composited")
+	cmd.Flags().StringSliceVar(&categories, "category", nil, "the IDs of the categories to catalogue (default all)")
+	cmd.Flags().IntVar(&cell, "cell", catalogue.DefaultCell, "the size of each gopher")
+	cmd.Flags().IntVar(&columns, "columns", catalogue.DefaultColumns, "the number of gophers in each row of the contact sheet")
+	cmd.Flags().StringVar(&bg, "bg", "", "the background colour, as hex RGB, RRGGBB or RRGGBBAA (default white)")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return fmt.Errorf("unexpected arguments: %v", args)
+		}
+		if out == "" && html == "" {
+			return fmt.Errorf("at least one of -o or --html is required")
+		}
+		if cell < 1 || cell > maxCatalogueCell {
+			return fmt.Errorf("invalid --cell %v; must be between 1 and %v", cell, maxCatalogueCell)
+		}
+		if columns < 1 {
+			return fmt.Errorf("invalid --columns %v", columns)
+		}
+
+		opts := catalogue.Options{
+			Categories: categories,
+			Cell:       cell,
+			Columns:    columns,
+		}
+
+		if base != "" {
+			r, err := gopher.Decode(base)
+			if err != nil {
+				return fmt.Errorf("invalid --base: %v", err)
+			}
+			opts.Base = r
+		}
+
+		if bg != "" {
+			c, err := compositor.ParseColor(bg)
+			if err != nil {
+				return fmt.Errorf("invalid --bg: %v", err)
+			}
+			opts.Background = c
+		}
+
+		m, err := loadArtwork()
+		if err != nil {
+			return err
+		}
+
+		es, err := catalogue.Entries(compositor.New(fArtwork, m), opts)
+		if err != nil {
+			return err
+		}
+
+		if out != "" {
+			if err := writeImage(out, catalogue.Sheet(es, opts)); err != nil {
+				return err
+			}
+		}
+
+		if html != "" {
+			if err := writeCatalogueHTML(html, es, opts); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	return cmd
+}
+
+// writeCatalogueHTML writes dir/index.html and an image of each entry to
+// dir/category/option.png
+func writeCatalogueHTML(dir string, es []catalogue.Entry, opts catalogue.Options) error {
+	src := func(e catalogue.Entry) string {
+		return path.Join(e.Category.ID, e.Option.ID+".png")
+	}
+
+	for _, e := range es {
+		fn := filepath.Join(dir, filepath.FromSlash(src(e)))
+		if err := os.MkdirAll(filepath.Dir(fn), 0755); err != nil {
+			return err
+		}
+		if err := writeImage(fn, e.Image); err != nil {
+			return err
+		}
+	}
+
+	fn := filepath.Join(dir, "index.html")
+
+	f, err := os.Create(fn)
+	if err != nil {
+		return err
+	}
+
+	if err := catalogue.HTML(f, es, opts, src); err != nil {
+		f.Close()
+		return fmt.Errorf("could not write %v: %v", fn, err)
+	}
+
+	return f.Close()
+}
+
+// writeImage encodes img, which is not a single gopher, as a PNG to the file
+// fn
+func writeImage(fn string, img image.Image) error {
+	f, err := os.Create(fn)
+	if err != nil {
+		return err
+	}
+
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return fmt.Errorf("could not encode %v: %v", fn, err)
+	}
+
+	return f.Close()
+}
@@ -0,0 +1,195 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/myitcv/gopherize.me/compositor"
+	"github.com/myitcv/gopherize.me/export"
+	"github.com/myitcv/gopherize.me/gopher"
+)
+
+// exportFormat is a format that the export command can write
+type exportFormat struct {
+	// ext is the file extension that implies the format
+	ext string
+
+	write func(w io.Writer, ls []compositor.Layer, o exportOpts) error
+}
+
+// exportOpts are the options common to every export format
+type exportOpts struct {
+	recipe gopher.Recipe
+	filter compositor.Filter
+	bg     color.Color
+}
+
+// flatten returns the flattened gopher of ls on o's background
+func (o exportOpts) flatten(ls []compositor.Layer) image.Image {
+	img := compositor.Flatten(ls)
+	if o.bg != nil {
+		return compositor.Background(img, o.bg)
+	}
+	return img
+}
+
+var exportFormats = map[string]exportFormat{
+	"ico": {
+		ext: ".ico",
+		write: func(w io.Writer, ls []compositor.Layer, o exportOpts) error {
+			return export.ICO(w, o.flatten(ls), export.FaviconSizes, o.filter)
+		},
+	},
+	"icons": {
+		ext: ".zip",
+		write: func(w io.Writer, ls []compositor.Layer, o exportOpts) error {
+			return export.IconSet(w, o.flatten(ls), o.filter)
+		},
+	},
+	"ora": {
+		ext: ".ora",
+		write: func(w io.Writer, ls []compositor.Layer, o exportOpts) error {
+			return export.ORA(w, ls, o.bg)
+		},
+	},
+	"svg": {
+		ext: ".svg",
+		write: func(w io.Writer, ls []compositor.Layer, o exportOpts) error {
+			return export.SVG(w, ls, o.recipe, o.bg)
+		},
+	},
+}
+
+func exportFormatNames() string {
+	var ns []string
+	for n := range exportFormats {
+		ns = append(ns, n)
+	}
+	sort.Strings(ns)
+	return strings.Join(ns, ", ")
+}
+
+func exportCmd() *cobra.Command {
+	var (
+		recipe string
+		out    string
+		format string
+		filter string
+		bg     string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export --recipe recipe [--format format] -o out",
+		Short: "export a recipe as a favicon, icon set, layered image or SVG",
+		Long: `export writes the gopher described by recipe in one of the formats:
+
+  ico    a favicon containing 16, 32, 48 and 64 pixel images
+  icons  a zip of favicon.ico, the usual web app icons and a site.webmanifest
+         fragment listing them
+  ora    an OpenRaster image with one layer per option, for editing in
+         Krita, GIMP and the like
+  svg    a scalable SVG image with one group per option, with the recipe in
+         its metadata
+
+If --format is not given it is inferred from the extension of the output file.`,
+		Example: "  " + gopherizeCmd + " export --recipe 1.body-blue_gopher.eyes-eyes -o favicon.ico",
+	}
+
+	cmd.Flags().StringVar(&recipe, "recipe", "", "the recipe of the gopher to export")
+	cmd.Flags().StringVarP(&out, "output", "o", "", "the file to which to write")
+	cmd.Flags().StringVar(&format, "format", "", "the format to write: one of "+exportFormatNames())
+	cmd.Flags().StringVar(&filter, "filter", compositor.CatmullRom.String(), "the scaling filter, catmullrom or box")
+	cmd.Flags().StringVar(&bg, "bg", "", "the background colour, as hex RGB, RRGGBB or RRGGBBAA (default transparent)")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return fmt.Errorf("unexpected arguments: %v", args)
+		}
+		if recipe == "" {
+			return fmt.Errorf("--recipe is required")
+		}
+		if out == "" {
+			return fmt.Errorf("-o is required")
+		}
+
+		if format == "" {
+			ext := filepath.Ext(out)
+			for n, ef := range exportFormats {
+				if ef.ext == ext {
+					format = n
+				}
+			}
+			if format == "" {
+				return fmt.Errorf("cannot infer format from %v; use --format", out)
+			}
+		}
+
+		ef, ok := exportFormats[format]
+		if !ok {
+			return fmt.Errorf("unknown --format %q; expected one of %v", format, exportFormatNames())
+		}
+
+		f, err := compositor.ParseFilter(filter)
+		if err != nil {
+			return fmt.Errorf("invalid --filter: %v", err)
+		}
+
+		o := exportOpts{
+			filter: f,
+		}
+
+		if bg != "" {
+			c, err := compositor.ParseColor(bg)
+			if err != nil {
+				return fmt.Errorf("invalid --bg: %v", err)
+			}
+			o.bg = c
+		}
+
+		m, err := loadArtwork()
+		if err != nil {
+			return err
+		}
+
+		rec, err := gopher.Decode(recipe)
+		if err != nil {
+			return err
+		}
+		if err := rec.Validate(m); err != nil {
+			return err
+		}
+		rec = rec.Resolve(m, gopher.Ref{})
+
+		o.recipe = rec
+
+		ls, err := compositor.New(fArtwork, m).Layers(rec)
+		if err != nil {
+			return err
+		}
+
+		fo, err := os.Create(out)
+		if err != nil {
+			return err
+		}
+
+		if err := ef.write(fo, ls, o); err != nil {
+			fo.Close()
+			return fmt.Errorf("could not write %v: %v", out, err)
+		}
+
+		return fo.Close()
+	}
+
+	return cmd
+}
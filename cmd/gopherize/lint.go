@@ -0,0 +1,69 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/myitcv/gopherize.me/artwork/lint"
+)
+
+func lintCmd() *cobra.Command {
+	var warnings bool
+
+	cmd := &cobra.Command{
+		Use:   "lint",
+		Short: "check the artwork for problems",
+		Long: `lint checks the artwork directory for missing thumbnail pairs, duplicate
+options, misplaced options and images whose size does not match the rest of
+their kind. It fails if any of these are found.
+
+lint also warns about options that have no artist or licence in their
+category's meta.json. Since most options have none yet, only the number of
+warnings is printed unless --warnings is given. Warnings alone do not cause
+lint to fail.`,
+	}
+
+	cmd.Flags().BoolVar(&warnings, "warnings", false, "list every warning rather than just their number")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return fmt.Errorf("unexpected arguments: %v", args)
+		}
+
+		problems, err := lint.Dir(fArtwork)
+		if err != nil {
+			return fmt.Errorf("could not lint %v: %v", fArtwork, err)
+		}
+
+		errs, warns := 0, 0
+
+		for _, p := range problems {
+			if p.Severity == lint.Warning {
+				warns++
+				if !warnings {
+					continue
+				}
+			} else {
+				errs++
+			}
+
+			fmt.Println(p)
+		}
+
+		if warns > 0 && !warnings {
+			fmt.Printf("%v warning(s); run with --warnings to list them\n", warns)
+		}
+
+		if errs > 0 {
+			return fmt.Errorf("found %v problem(s)", errs)
+		}
+
+		return nil
+	}
+
+	return cmd
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+/*
+gopherize renders gophers, and checks and serves the artwork from which they
+are built, without a browser.
+
+Usage:
+
+	gopherize [--artwork dir] command [flags]
+
+The commands are:
+
+	render      render a recipe as a PNG image
+	random      render random gophers as PNG images
+	avatar      derive the avatar gopher of an identity
+	export      export a recipe as a favicon, icon set, layered image or SVG
+	catalogue   render a contact sheet of every artwork option
+	manifest    print the manifest of the artwork as JSON
+	lint        check the artwork for problems
+	serve       serve the client, the artwork and the render API
+
+--artwork, which defaults to artwork, is the artwork directory used by every
+command. Run gopherize help command for the flags of each command.
+*/
+package main
+
+import (
+	"fmt"
+	"image"
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/myitcv/gopherize.me/artwork/scan"
+	"github.com/myitcv/gopherize.me/compositor"
+	"github.com/myitcv/gopherize.me/export"
+	"github.com/myitcv/gopherize.me/gopher"
+)
+
+const (
+	gopherizeCmd = "gopherize"
+)
+
+var (
+	fArtwork string
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix(gopherizeCmd + ": ")
+
+	root := &cobra.Command{
+		Use:   gopherizeCmd,
+		Short: "gopherize renders gophers and checks and serves their artwork",
+
+		// errors are reported by Execute; usage is only helpful for mistakes
+		// in the command line, which cobra reports before any command runs
+		SilenceUsage: true,
+	}
+
+	root.PersistentFlags().StringVar(&fArtwork, "artwork", "artwork", "the artwork directory")
+
+	root.AddCommand(
+		renderCmd(),
+		randomCmd(),
+		avatarCmd(),
+		exportCmd(),
+		catalogueCmd(),
+		manifestCmd(),
+		lintCmd(),
+		serveCmd(),
+	)
+
+	if err := root.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+// loadArtwork scans the artwork directory
+func loadArtwork() (*gopher.Manifest, error) {
+	res, err := scan.Dir(fArtwork)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Manifest, nil
+}
+
+// addRenderFlags adds the flags that control how a gopher is finished to cmd,
+// returning a function that parses them
+func addRenderFlags(cmd *cobra.Command) func() (compositor.Options, error) {
+	size := cmd.Flags().Int("size", 0, fmt.Sprintf("scale the gopher to fit a square of this many pixels, at most %v (default the size of the artwork)", compositor.MaxSize))
+	square := cmd.Flags().Bool("square", false, "pad the gopher to a square")
+	preset := cmd.Flags().String("preset", "", "a named size, which sets --size and --square: one of "+compositor.PresetNames())
+	filter := cmd.Flags().String("filter", compositor.CatmullRom.String(), "the scaling filter, catmullrom or box")
+	bg := cmd.Flags().String("bg", "", "the background colour, as hex RGB, RRGGBB or RRGGBBAA (default transparent)")
+
+	return func() (compositor.Options, error) {
+		var res compositor.Options
+
+		if *preset != "" {
+			p, ok := compositor.LookupPreset(*preset)
+			if !ok {
+				return res, fmt.Errorf("unknown --preset %q; expected one of %v", *preset, compositor.PresetNames())
+			}
+			res = p.Options()
+		}
+
+		if cmd.Flags().Changed("square") {
+			res.Square = *square
+		}
+
+		if cmd.Flags().Changed("size") {
+			if *size < 0 || *size > compositor.MaxSize {
+				return res, fmt.Errorf("invalid --size %v; expected at most %v", *size, compositor.MaxSize)
+			}
+			res.Size = *size
+		}
+
+		f, err := compositor.ParseFilter(*filter)
+		if err != nil {
+			return res, fmt.Errorf("invalid --filter: %v", err)
+		}
+		res.Filter = f
+
+		if *bg != "" {
+			c, err := compositor.ParseColor(*bg)
+			if err != nil {
+				return res, fmt.Errorf("invalid --bg: %v", err)
+			}
+			res.Background = c
+		}
+
+		return res, nil
+	}
+}
+
+// writePNG encodes img, the gopher r rendered from the artwork described by
+// m, as a PNG to the file fn. The recipe and artwork version are embedded in
+// the image.
+func writePNG(fn string, img image.Image, r gopher.Recipe, m *gopher.Manifest) error {
+	f, err := os.Create(fn)
+	if err != nil {
+		return err
+	}
+
+	if err := export.PNG(f, img, r, m.Version); err != nil {
+		f.Close()
+		return fmt.Errorf("could not encode %v: %v", fn, err)
+	}
+
+	return f.Close()
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+func manifestCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "manifest",
+		Short: "print the manifest of the artwork as JSON",
+		Long: `manifest scans the artwork directory and prints the categories and options
+found there as JSON. Unlike the manifest compiled into the client by
+manifestGen, it always reflects the artwork on disk.`,
+	}
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return fmt.Errorf("unexpected arguments: %v", args)
+		}
+
+		m, err := loadArtwork()
+		if err != nil {
+			return err
+		}
+
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "\t")
+
+		return enc.Encode(m)
+	}
+
+	return cmd
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/myitcv/gopherize.me/compositor"
+	"github.com/myitcv/gopherize.me/gopher"
+)
+
+func randomCmd() *cobra.Command {
+	var (
+		seed  int64
+		count int
+		out   string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "random [--seed N] [--count N] -o dir",
+		Short: "render random gophers as PNG images",
+		Long: `random renders count random gophers into the directory dir, creating it if
+necessary. The gophers are generated from the seeds seed, seed+1 and so on, so
+the same seed and count always produce the same gophers. Each image is named
+after its recipe, which is also printed. If no seed is given, the seed used is
+logged.`,
+	}
+
+	cmd.Flags().Int64Var(&seed, "seed", 0, "the seed of the first gopher (default based on the current time)")
+	cmd.Flags().IntVar(&count, "count", 1, "the number of gophers to render")
+	cmd.Flags().StringVarP(&out, "output", "o", "", "the directory to which to write the PNG images")
+	opts := addRenderFlags(cmd)
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return fmt.Errorf("unexpected arguments: %v", args)
+		}
+		if out == "" {
+			return fmt.Errorf("-o is required")
+		}
+		if count < 1 {
+			return fmt.Errorf("invalid --count %v", count)
+		}
+		if !cmd.Flags().Changed("seed") {
+			seed = time.Now().UnixNano()
+
+			// so that the gophers can be rendered again
+			log.Printf("using --seed %v", seed)
+		}
+
+		o, err := opts()
+		if err != nil {
+			return err
+		}
+
+		m, err := loadArtwork()
+		if err != nil {
+			return err
+		}
+
+		if err := os.MkdirAll(out, 0755); err != nil {
+			return err
+		}
+
+		c := compositor.New(fArtwork, m)
+
+		for i := 0; i < count; i++ {
+			rec := gopher.Random(m, seed+int64(i))
+
+			img, err := c.Render(rec, o)
+			if err != nil {
+				return err
+			}
+
+			fn := filepath.Join(out, rec.Encode()+".png")
+			if err := writePNG(fn, img, rec, m); err != nil {
+				return err
+			}
+
+			fmt.Println(rec)
+		}
+
+		return nil
+	}
+
+	return cmd
+}
@@ -0,0 +1,150 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/myitcv/gopherize.me/compositor"
+	"github.com/myitcv/gopherize.me/export"
+	"github.com/myitcv/gopherize.me/gopher"
+	"github.com/myitcv/gopherize.me/pngmeta"
+)
+
+func renderCmd() *cobra.Command {
+	var (
+		recipe string
+		from   string
+		colour string
+		out    string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "render (--recipe recipe | --from file) -o out.png",
+		Short: "render a recipe as a PNG image",
+		Long: `render renders the gopher described by recipe as a PNG image, embedding the
+recipe and the version of the artwork in the image. With --from the recipe is
+instead read from a PNG or SVG image previously written by gopherize.
+
+With --colour the body, and any ears drawn as part of the hair, are recoloured
+to the given colour, which is recorded in the recipe. The colour is given as
+hex RRGGBB, or as the ID of a body whose colour to use, e.g. pink_gopher.`,
+		Example: "  " + gopherizeCmd + " render --recipe 1.body-blue_gopher.eyes-eyes --size 256 -o gopher.png",
+	}
+
+	cmd.Flags().StringVar(&recipe, "recipe", "", "the recipe of the gopher to render")
+	cmd.Flags().StringVar(&from, "from", "", "a PNG or SVG image from which to read the recipe")
+	cmd.Flags().StringVar(&colour, "colour", "", "recolour the gopher, given as hex RRGGBB or the ID of a body, e.g. pink_gopher")
+	cmd.Flags().StringVarP(&out, "output", "o", "", "the file to which to write the PNG image")
+	opts := addRenderFlags(cmd)
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return fmt.Errorf("unexpected arguments: %v", args)
+		}
+		if (recipe == "") == (from == "") {
+			return fmt.Errorf("exactly one of --recipe or --from is required")
+		}
+		if out == "" {
+			return fmt.Errorf("-o is required")
+		}
+
+		o, err := opts()
+		if err != nil {
+			return err
+		}
+
+		m, err := loadArtwork()
+		if err != nil {
+			return err
+		}
+
+		var rec gopher.Recipe
+		if from != "" {
+			rec, err = readRecipe(from, m)
+		} else {
+			rec, err = gopher.Decode(recipe)
+		}
+		if err != nil {
+			return err
+		}
+		if colour != "" {
+			hex, err := parseColour(colour, m)
+			if err != nil {
+				return fmt.Errorf("invalid --colour: %v", err)
+			}
+			rec = rec.WithColour(hex)
+		}
+		if err := rec.Validate(m); err != nil {
+			return err
+		}
+		rec = rec.Resolve(m, gopher.Ref{})
+
+		img, err := compositor.New(fArtwork, m).Render(rec, o)
+		if err != nil {
+			return err
+		}
+
+		return writePNG(out, img, rec, m)
+	}
+
+	return cmd
+}
+
+// parseColour parses a colour given as hex RRGGBB, optionally preceded by #,
+// or as the ID of an option of m with a colour, returning it in the form
+// recorded in a recipe
+func parseColour(s string, m *gopher.Manifest) (string, error) {
+	var ids []string
+	for _, o := range m.Swatches() {
+		if o.ID == s {
+			return o.Colour, nil
+		}
+		ids = append(ids, o.ID)
+	}
+
+	h := strings.ToLower(strings.TrimPrefix(s, "#"))
+	if !gopher.ValidColour(h) {
+		return "", fmt.Errorf("%q is neither hex RRGGBB nor one of %v", s, strings.Join(ids, ", "))
+	}
+
+	return h, nil
+}
+
+// readRecipe reads the recipe embedded in the PNG or SVG image fn, warning if
+// it was rendered from a different version of the artwork than m
+func readRecipe(fn string, m *gopher.Manifest) (gopher.Recipe, error) {
+	f, err := os.Open(fn)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var rec gopher.Recipe
+	var version string
+
+	switch ext := strings.ToLower(filepath.Ext(fn)); ext {
+	case ".png":
+		rec, version, err = pngmeta.ReadRecipe(f)
+	case ".svg":
+		rec, err = export.SVGRecipe(f)
+	default:
+		return nil, fmt.Errorf("cannot read a recipe from %v; expected a .png or .svg image", fn)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read recipe from %v: %v", fn, err)
+	}
+
+	if version != "" && version != m.Version {
+		log.Printf("warning: %v was rendered from artwork version %v; the artwork is now version %v", fn, version, m.Version)
+	}
+
+	return rec, nil
+}
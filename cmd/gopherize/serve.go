@@ -0,0 +1,87 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/myitcv/gopherize.me/avatar"
+	"github.com/myitcv/gopherize.me/server"
+)
+
+func serveCmd() *cobra.Command {
+	var (
+		addr      string
+		client    string
+		pool      string
+		registry  string
+		redirects []string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "serve the client, the artwork and the render API",
+		Long: `serve serves the client app, the artwork beneath /artwork/ and rendered
+gophers beneath /render/ and avatars beneath /avatar/. The client directory must contain client.js, built
+with:
+
+  gopherjs build -o client/client.js github.com/myitcv/gopherize.me/client`,
+	}
+
+	cmd.Flags().StringVar(&addr, "addr", "localhost:8080", "the address on which to listen")
+	cmd.Flags().StringVar(&client, "client", "client", "the directory containing index.html and client.js")
+	cmd.Flags().StringVar(&pool, "avatar-pool", "", "a JSON file restricting the options from which avatars are derived (default every option)")
+	cmd.Flags().StringVar(&registry, "avatar-registry", "", "a JSON file mapping identities to chosen recipes")
+	cmd.Flags().StringSliceVar(&redirects, "avatar-redirect", nil, "a host to which the d parameter of an avatar request may redirect; may be repeated (default none)")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return fmt.Errorf("unexpected arguments: %v", args)
+		}
+
+		if _, err := os.Stat(filepath.Join(client, "index.html")); err != nil {
+			return fmt.Errorf("%v does not look like the client directory: %v", client, err)
+		}
+
+		if _, err := os.Stat(filepath.Join(client, "client.js")); err != nil {
+			log.Printf("warning: %v; has the client been built with gopherjs?", err)
+		}
+
+		m, err := loadArtwork()
+		if err != nil {
+			return err
+		}
+
+		srv := server.New(client, fArtwork, m)
+
+		var p avatar.Pool
+		if pool != "" {
+			if p, err = avatar.LoadPool(pool); err != nil {
+				return err
+			}
+		}
+
+		var reg avatar.Registry
+		if registry != "" {
+			if reg, err = avatar.LoadRegistry(registry, m); err != nil {
+				return err
+			}
+		}
+
+		srv.SetAvatars(p, reg)
+		srv.SetAvatarRedirects(redirects...)
+
+		log.Printf("serving on http://%v/", addr)
+
+		return http.ListenAndServe(addr, srv)
+	}
+
+	return cmd
+}
@@ -0,0 +1,173 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+/*
+manifestGen is a go generate generator that walks the NNN-Category
+directories of the artwork package and writes a Go manifest of the categories
+and options found there.
+*/
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/myitcv/gopherize.me/artwork/scan"
+	"github.com/myitcv/gopherize.me/gopher"
+)
+
+const (
+	manifestGenCmd = "manifestGen"
+
+	outFile = "gen_manifest_" + manifestGenCmd + ".go"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix(manifestGenCmd + ": ")
+
+	flag.Parse()
+
+	wd, err := os.Getwd()
+	if err != nil {
+		fatalf("unable to get working directory: %v", err)
+	}
+
+	pkg, ok := os.LookupEnv("GOPACKAGE")
+	if !ok {
+		fatalf("env not correct; missing GOPACKAGE")
+	}
+
+	res, err := scan.Dir(wd)
+	if err != nil {
+		fatalf("could not scan artwork: %v", err)
+	}
+
+	for _, o := range res.Orphans {
+		log.Printf("skipping thumbnail %v; it has no full-size image", o)
+	}
+
+	for _, u := range res.Unknown {
+		log.Printf("ignoring metadata for %v; it does not exist", u)
+	}
+
+	for _, c := range res.Manifest.Categories {
+		for _, o := range c.Options {
+			if o.Thumbnail == "" {
+				log.Printf("option %v/%v has no thumbnail", c.ID, o.ID)
+			}
+		}
+	}
+
+	buf := bytes.NewBuffer(nil)
+
+	t, err := template.New("t").Funcs(template.FuncMap{
+		"refs": refs,
+	}).Parse(tmpl)
+	if err != nil {
+		fatalf("could not parse template: %v", err)
+	}
+
+	err = t.Execute(buf, struct {
+		Pkg string
+		*scan.Result
+	}{
+		Pkg:    pkg,
+		Result: res,
+	})
+	if err != nil {
+		fatalf("could not execute template: %v", err)
+	}
+
+	toWrite := buf.Bytes()
+	out, err := format.Source(toWrite)
+	if err == nil {
+		toWrite = out
+	}
+
+	fp := filepath.Join(wd, outFile)
+
+	if prev, err := ioutil.ReadFile(fp); err == nil && bytes.Equal(prev, toWrite) {
+		return
+	}
+
+	if err := ioutil.WriteFile(fp, toWrite, 0644); err != nil {
+		fatalf("could not write %v: %v", fp, err)
+	}
+}
+
+// refs returns the Go source of a []gopher.Ref literal holding fs
+func refs(fs []gopher.Ref) string {
+	var parts []string
+	for _, f := range fs {
+		parts = append(parts, fmt.Sprintf("{Category: %q, Option: %q}", f.Category, f.Option))
+	}
+
+	return "[]gopher.Ref{" + strings.Join(parts, ", ") + "}"
+}
+
+func fatalf(format string, args ...interface{}) {
+	panic(fmt.Errorf(format, args...))
+}
+
+var tmpl = `
+// Code generated by manifestGen. DO NOT EDIT.
+
+package {{.Pkg}}
+
+import "github.com/myitcv/gopherize.me/gopher"
+
+// Default is the manifest of the artwork in this directory
+var Default = &gopher.Manifest{
+	Version: {{printf "%q" .Manifest.Version}},
+	Categories: []*gopher.Category{
+		{{- range .Manifest.Categories}}
+		{
+			ID:    {{printf "%q" .ID}},
+			Name:  {{printf "%q" .Name}},
+			Dir:   {{printf "%q" .Dir}},
+			Order: {{.Order}},
+			{{- if .Multi}}
+			Multi: true,
+			{{- end}}
+			Options: []*gopher.Option{
+				{{- range .Options}}
+				{ID: {{printf "%q" .ID}}, Name: {{printf "%q" .Name}}, Image: {{printf "%q" .Image}}, Thumbnail: {{printf "%q" .Thumbnail}}
+					{{- with .Tags}}, Tags: {{printf "%#v" .}}{{end}}
+					{{- with .Artist}}, Artist: {{printf "%q" .}}{{end}}
+					{{- with .Licence}}, Licence: {{printf "%q" .}}{{end}}
+					{{- if .Sponsored}}, Sponsored: true{{end}}
+					{{- with .Colour}}, Colour: {{printf "%q" .}}{{end}}},
+				{{- end}}
+			},
+		},
+		{{- end}}
+	},
+	{{- with .Manifest.Rules}}
+	Rules: []*gopher.Rule{
+		{{- range .}}
+		{
+			If: gopher.Ref{Category: {{printf "%q" .If.Category}}, Option: {{printf "%q" .If.Option}}},
+			{{- with .Excludes}}
+			Excludes: {{refs .}},
+			{{- end}}
+			{{- with .Requires}}
+			Requires: {{refs .}},
+			{{- end}}
+			{{- with .Prefers}}
+			Prefers: {{refs .}},
+			{{- end}}
+		},
+		{{- end}}
+	},
+	{{- end}}
+}
+`
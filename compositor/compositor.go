@@ -0,0 +1,129 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+// Package compositor flattens a selection of artwork layers into a single
+// image.
+//
+// Compositing is pure Go and deterministic: the same selection of the same
+// artwork always produces the same pixels.
+package compositor
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	"image/png"
+	"os"
+	"path/filepath"
+
+	"github.com/myitcv/gopherize.me/gopher"
+)
+
+// Layer is a single decoded layer of a gopher
+type Layer struct {
+	gopher.Layer
+
+	Image image.Image
+}
+
+// Compositor composites the artwork found beneath an artwork root directory
+type Compositor struct {
+	root     string
+	manifest *gopher.Manifest
+}
+
+// New returns a Compositor for the artwork described by m, the images for
+// which are found beneath the directory root
+func New(root string, m *gopher.Manifest) *Compositor {
+	return &Compositor{
+		root:     root,
+		manifest: m,
+	}
+}
+
+// Manifest returns the manifest of the artwork that c composites
+func (c *Compositor) Manifest() *gopher.Manifest {
+	return c.manifest
+}
+
+// Layers validates r against the manifest and decodes the images of its
+// layers, recolouring them to the colour r chooses, if any. The layers are
+// returned in the order in which they should be drawn.
+func (c *Compositor) Layers(r gopher.Recipe) ([]Layer, error) {
+	if err := r.Validate(c.manifest); err != nil {
+		return nil, err
+	}
+
+	var res []Layer
+
+	for _, l := range r.Layers(c.manifest) {
+		dl, err := c.Layer(l.Category, l.Option)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := recolour(&dl, r.Colour()); err != nil {
+			return nil, err
+		}
+
+		res = append(res, dl)
+	}
+
+	return res, nil
+}
+
+// Layer decodes the image of option o in category cat. Unlike Layers, it does
+// not check the option against the manifest's rules.
+func (c *Compositor) Layer(cat *gopher.Category, o *gopher.Option) (Layer, error) {
+	img, err := c.decode(o.Image)
+	if err != nil {
+		return Layer{}, err
+	}
+
+	return Layer{
+		Layer: gopher.Layer{Category: cat, Option: o},
+		Image: img,
+	}, nil
+}
+
+// Composite flattens the layers of r into a single image
+func (c *Compositor) Composite(r gopher.Recipe) (*image.RGBA, error) {
+	ls, err := c.Layers(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return Flatten(ls), nil
+}
+
+func (c *Compositor) decode(p string) (image.Image, error) {
+	f, err := os.Open(filepath.Join(c.root, filepath.FromSlash(p)))
+	if err != nil {
+		return nil, fmt.Errorf("could not open layer %v: %v", p, err)
+	}
+	defer f.Close()
+
+	img, err := png.Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode layer %v: %v", p, err)
+	}
+
+	return img, nil
+}
+
+// Flatten draws ls, in order, onto a transparent canvas large enough to hold
+// all of them
+func Flatten(ls []Layer) *image.RGBA {
+	var bounds image.Rectangle
+	for _, l := range ls {
+		bounds = bounds.Union(l.Image.Bounds())
+	}
+
+	res := image.NewRGBA(bounds)
+
+	for _, l := range ls {
+		draw.Draw(res, l.Image.Bounds(), l.Image, l.Image.Bounds().Min, draw.Over)
+	}
+
+	return res
+}
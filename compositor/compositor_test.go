@@ -0,0 +1,135 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package compositor_test
+
+import (
+	"flag"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/myitcv/gopherize.me/artwork"
+	"github.com/myitcv/gopherize.me/compositor"
+	"github.com/myitcv/gopherize.me/gopher"
+)
+
+var fUpdate = flag.Bool("update", false, "rewrite the golden images in testdata")
+
+const (
+	// the golden images are rendered from the real artwork, so must be
+	// rewritten with -update when the artwork they use changes
+	artworkRoot = "../artwork"
+	goldenDir   = "testdata"
+
+	// tolerance is the largest difference allowed in any channel of any pixel.
+	// Rendering is deterministic on a given platform, but Go allows floating
+	// point operations to be fused on some architectures, which can change
+	// the rounding of the odd pixel.
+	tolerance = 1
+)
+
+func TestComposite(t *testing.T) {
+	r, err := gopher.Decode("1.body-blue_gopher.eyes-eyes")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := compositor.New(artworkRoot, artwork.Default)
+
+	got, err := c.Composite(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// compositing twice gives the same pixels
+	again, err := c.Composite(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Rect.Eq(again.Rect) || string(got.Pix) != string(again.Pix) {
+		t.Fatalf("compositing %v twice gave different images", r)
+	}
+
+	checkGolden(t, filepath.Join(goldenDir, "composite.png"), got)
+
+	// the body and eyes alone are the body with the eyes drawn over it
+	ls, err := c.Layers(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ls) != 2 {
+		t.Fatalf("got %v layers; want 2", len(ls))
+	}
+
+	want := image.NewRGBA(ls[0].Image.Bounds())
+	draw.Draw(want, want.Rect, ls[0].Image, want.Rect.Min, draw.Src)
+	draw.Draw(want, want.Rect, ls[1].Image, want.Rect.Min, draw.Over)
+
+	if !got.Rect.Eq(want.Rect) || string(got.Pix) != string(want.Pix) {
+		t.Fatalf("Composite(%v) differs from drawing its layers in turn", r)
+	}
+
+	if _, err := c.Composite(gopher.Recipe{"body": {"no_such_body"}}); err == nil {
+		t.Fatalf("Composite of an unknown option succeeded")
+	}
+}
+
+// checkGolden compares img with the golden image fn, or rewrites fn with img
+// if -update is given
+func checkGolden(t *testing.T, fn string, img image.Image) {
+	t.Helper()
+
+	if *fUpdate {
+		f, err := os.Create(fn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := png.Encode(f, img); err != nil {
+			f.Close()
+			t.Fatal(err)
+		}
+		if err := f.Close(); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	f, err := os.Open(fn)
+	if err != nil {
+		t.Fatalf("%v; run go test -update to create it", err)
+	}
+	defer f.Close()
+
+	want, err := png.Decode(f)
+	if err != nil {
+		t.Fatalf("could not decode %v: %v", fn, err)
+	}
+
+	if !img.Bounds().Eq(want.Bounds()) {
+		t.Fatalf("got bounds %v; want %v from %v", img.Bounds(), want.Bounds(), fn)
+	}
+
+	b := img.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			g := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			w := color.NRGBAModel.Convert(want.At(x, y)).(color.NRGBA)
+
+			if diff(g.R, w.R) > tolerance || diff(g.G, w.G) > tolerance || diff(g.B, w.B) > tolerance || diff(g.A, w.A) > tolerance {
+				t.Fatalf("pixel (%v, %v) is %v; want %v from %v", x, y, g, w, fn)
+			}
+		}
+	}
+}
+
+func diff(a, b uint8) int {
+	if a > b {
+		return int(a - b)
+	}
+	return int(b - a)
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package compositor
+
+import (
+	"image"
+	"image/draw"
+
+	"github.com/myitcv/gopherize.me/tint"
+)
+
+// Recolour returns a copy of img in which the parts drawn in the key colour
+// from, and its shades, are recoloured to the colour to
+func Recolour(img image.Image, from, to tint.RGB) *image.NRGBA {
+	b := img.Bounds()
+
+	res := image.NewNRGBA(b)
+	draw.Draw(res, b, img, b.Min, draw.Src)
+
+	tint.Pix(res.Pix, from, to)
+
+	return res
+}
+
+// recolour recolours l to the colour hex if its option can be recoloured
+func recolour(l *Layer, hex string) error {
+	if hex == "" || l.Option.Colour == "" {
+		return nil
+	}
+
+	from, err := tint.ParseHex(l.Option.Colour)
+	if err != nil {
+		return err
+	}
+
+	to, err := tint.ParseHex(hex)
+	if err != nil {
+		return err
+	}
+
+	l.Image = Recolour(l.Image, from, to)
+
+	return nil
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package compositor
+
+import (
+	"encoding/hex"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"strings"
+
+	"github.com/myitcv/gopherize.me/gopher"
+)
+
+// MaxSize is the largest Size that may be asked of the render API or the
+// gopherize command. Larger images take too long and too much memory to
+// render to be worth serving.
+const MaxSize = 2048
+
+// Options control how a composited gopher is finished
+type Options struct {
+	// Size is the size of the square into which the gopher is scaled to
+	// fit; zero leaves the gopher at the size of the artwork
+	Size int
+
+	// Filter is the filter used to scale the gopher
+	Filter Filter
+
+	// Square pads the gopher with transparent space, either side, to make it
+	// square before it is scaled
+	Square bool
+
+	// Background fills the background of the gopher; nil leaves it
+	// transparent
+	Background color.Color
+}
+
+// Preset is a named, square size suited to a particular use of a gopher
+type Preset struct {
+	Name string
+	Size int
+}
+
+// Presets are the sizes at which gophers are most often wanted, smallest
+// first
+var Presets = []Preset{
+	{Name: "favicon", Size: 32},
+	{Name: "twitter", Size: 400},
+	{Name: "github", Size: 460},
+	{Name: "slack", Size: 512},
+}
+
+// LookupPreset returns the preset with the given name
+func LookupPreset(name string) (Preset, bool) {
+	for _, p := range Presets {
+		if p.Name == name {
+			return p, true
+		}
+	}
+
+	return Preset{}, false
+}
+
+// PresetNames returns the names of Presets, comma-separated
+func PresetNames() string {
+	var ns []string
+	for _, p := range Presets {
+		ns = append(ns, p.Name)
+	}
+	return strings.Join(ns, ", ")
+}
+
+// Options returns the options for rendering a gopher at p's size
+func (p Preset) Options() Options {
+	return Options{
+		Size:   p.Size,
+		Square: true,
+	}
+}
+
+// Render composites r and finishes the result according to opts
+func (c *Compositor) Render(r gopher.Recipe, opts Options) (*image.RGBA, error) {
+	img, err := c.Composite(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Square {
+		img = Square(img)
+	}
+
+	if opts.Size != 0 {
+		sz := Fit(img.Bounds(), opts.Size)
+		img = Scale(img, sz.X, sz.Y, opts.Filter)
+	}
+
+	if opts.Background != nil {
+		img = Background(img, opts.Background)
+	}
+
+	return img, nil
+}
+
+// Square returns img centred on a transparent square canvas whose side is
+// the larger of img's width and height
+func Square(img image.Image) *image.RGBA {
+	b := img.Bounds()
+
+	side := b.Dx()
+	if b.Dy() > side {
+		side = b.Dy()
+	}
+
+	res := image.NewRGBA(image.Rect(0, 0, side, side))
+	off := image.Pt((side-b.Dx())/2, (side-b.Dy())/2)
+	draw.Draw(res, b.Sub(b.Min).Add(off), img, b.Min, draw.Src)
+
+	return res
+}
+
+// Background returns img drawn over a canvas filled with bg
+func Background(img image.Image, bg color.Color) *image.RGBA {
+	res := image.NewRGBA(img.Bounds())
+	draw.Draw(res, res.Bounds(), image.NewUniform(bg), image.Point{}, draw.Src)
+	draw.Draw(res, res.Bounds(), img, img.Bounds().Min, draw.Over)
+
+	return res
+}
+
+// ParseColor parses a colour given as hex RGB, RRGGBB or RRGGBBAA, optionally
+// preceded by #
+func ParseColor(s string) (color.Color, error) {
+	h := strings.TrimPrefix(s, "#")
+
+	switch len(h) {
+	case 3:
+		h = string([]byte{h[0], h[0], h[1], h[1], h[2], h[2]}) + "ff"
+	case 6:
+		h += "ff"
+	case 8:
+	default:
+		return nil, fmt.Errorf("colour %q is not of the form RGB, RRGGBB or RRGGBBAA", s)
+	}
+
+	b, err := hex.DecodeString(h)
+	if err != nil {
+		return nil, fmt.Errorf("colour %q is not valid hex", s)
+	}
+
+	return color.NRGBA{R: b[0], G: b[1], B: b[2], A: b[3]}, nil
+}
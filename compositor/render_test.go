@@ -0,0 +1,118 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package compositor_test
+
+import (
+	"image"
+	"image/color"
+	"path/filepath"
+	"testing"
+
+	"github.com/myitcv/gopherize.me/artwork"
+	"github.com/myitcv/gopherize.me/compositor"
+	"github.com/myitcv/gopherize.me/gopher"
+)
+
+func TestRender(t *testing.T) {
+	plain, err := gopher.Decode("1.body-blue_gopher.eyes-eyes.shirts-docker_shirt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	white := color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+
+	tests := []struct {
+		name   string
+		recipe gopher.Recipe
+		opts   compositor.Options
+	}{
+		{"scaled", plain, compositor.Options{Size: 96}},
+		{"square", plain, compositor.Options{Size: 96, Square: true}},
+		{"box", plain, compositor.Options{Size: 64, Square: true, Filter: compositor.Box}},
+		{"background", plain, compositor.Options{Size: 96, Square: true, Background: white}},
+		{"colour", plain.WithColour("ff8800"), compositor.Options{Size: 96, Square: true}},
+	}
+
+	c := compositor.New(artworkRoot, artwork.Default)
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := c.Render(tc.recipe, tc.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// rendering twice gives the same pixels
+			again, err := c.Render(tc.recipe, tc.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got.Rect.Eq(again.Rect) || string(got.Pix) != string(again.Pix) {
+				t.Fatalf("rendering %v twice gave different images", tc.recipe)
+			}
+
+			checkGolden(t, filepath.Join(goldenDir, tc.name+".png"), got)
+		})
+	}
+}
+
+func TestSquare(t *testing.T) {
+	red := color.RGBA{R: 0xff, A: 0xff}
+
+	img := image.NewRGBA(image.Rect(10, 20, 14, 22))
+	for y := 20; y < 22; y++ {
+		for x := 10; x < 14; x++ {
+			img.SetRGBA(x, y, red)
+		}
+	}
+
+	got := compositor.Square(img)
+
+	if want := image.Rect(0, 0, 4, 4); !got.Rect.Eq(want) {
+		t.Fatalf("Square() has bounds %v; want %v", got.Rect, want)
+	}
+
+	// the image is centred vertically, with a transparent row above and below
+	for y := 0; y < 4; y++ {
+		want := color.RGBA{}
+		if y == 1 || y == 2 {
+			want = red
+		}
+		for x := 0; x < 4; x++ {
+			if c := got.RGBAAt(x, y); c != want {
+				t.Fatalf("Square() pixel (%v, %v) is %v; want %v", x, y, c, want)
+			}
+		}
+	}
+}
+
+func TestBackground(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	img.SetRGBA(0, 0, color.RGBA{R: 0x80, A: 0x80})
+
+	got := compositor.Background(img, color.NRGBA{B: 0xff, A: 0xff})
+
+	if c, want := got.RGBAAt(0, 0), (color.RGBA{R: 0x80, B: 0x7f, A: 0xff}); c != want {
+		t.Errorf("Background() pixel (0, 0) is %v; want %v", c, want)
+	}
+	if c, want := got.RGBAAt(1, 0), (color.RGBA{B: 0xff, A: 0xff}); c != want {
+		t.Errorf("Background() pixel (1, 0) is %v; want %v", c, want)
+	}
+}
+
+func TestPresets(t *testing.T) {
+	for _, p := range compositor.Presets {
+		got, ok := compositor.LookupPreset(p.Name)
+		if !ok || got != p {
+			t.Errorf("LookupPreset(%q) = %v, %v; want %v, true", p.Name, got, ok, p)
+		}
+		if o := p.Options(); o.Size != p.Size || !o.Square {
+			t.Errorf("%v.Options() = %+v; want a square of size %v", p.Name, o, p.Size)
+		}
+	}
+
+	if _, ok := compositor.LookupPreset("huge"); ok {
+		t.Errorf("LookupPreset(%q) succeeded", "huge")
+	}
+}
@@ -0,0 +1,250 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package compositor
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	"math"
+)
+
+// Filter is a resampling filter used by Scale. golang.org/x/image is not
+// vendored, so the filters are implemented here.
+type Filter int
+
+const (
+	// CatmullRom is the Catmull-Rom cubic filter, widened when shrinking so
+	// that every source pixel contributes. It is sharp, and the default.
+	CatmullRom Filter = iota
+
+	// Box averages the source pixels covered by each destination pixel,
+	// weighted by the area covered. It is softer than CatmullRom but never
+	// rings.
+	Box
+)
+
+var filterNames = map[Filter]string{
+	CatmullRom: "catmullrom",
+	Box:        "box",
+}
+
+func (f Filter) String() string {
+	if n, ok := filterNames[f]; ok {
+		return n
+	}
+	return fmt.Sprintf("Filter(%d)", int(f))
+}
+
+// ParseFilter returns the filter named s, one of catmullrom or box
+func ParseFilter(s string) (Filter, error) {
+	for f, n := range filterNames {
+		if n == s {
+			return f, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown filter %q; expected catmullrom or box", s)
+}
+
+// Fit returns the largest size no larger than size x size with the aspect
+// ratio of b
+func Fit(b image.Rectangle, size int) image.Point {
+	w, h := b.Dx(), b.Dy()
+	if w == 0 || h == 0 {
+		return image.Point{}
+	}
+
+	scale := func(a, b int) int {
+		res := int(math.Floor(float64(a)*float64(size)/float64(b) + 0.5))
+		if res < 1 {
+			res = 1
+		}
+		return res
+	}
+
+	if w >= h {
+		return image.Pt(size, scale(h, w))
+	}
+
+	return image.Pt(scale(w, h), size)
+}
+
+// Scale resamples src to w x h pixels using the filter f
+func Scale(src image.Image, w, h int, f Filter) *image.RGBA {
+	s, ok := src.(*image.RGBA)
+	if !ok {
+		s = image.NewRGBA(src.Bounds())
+		draw.Draw(s, s.Bounds(), src, src.Bounds().Min, draw.Src)
+	}
+
+	sb := s.Bounds()
+	sw, sh := sb.Dx(), sb.Dy()
+
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	if w <= 0 || h <= 0 || sw == 0 || sh == 0 {
+		return dst
+	}
+
+	weights := catmullRomWeights
+	if f == Box {
+		weights = boxWeights
+	}
+
+	xs := weights(sw, w)
+	ys := weights(sh, h)
+
+	// scale horizontally into tmp, w x sh, then vertically into dst. The
+	// pixels of an image.RGBA are alpha-premultiplied, so they can be
+	// averaged directly.
+	tmp := make([]float32, w*sh*4)
+
+	for y := 0; y < sh; y++ {
+		row := s.Pix[s.PixOffset(sb.Min.X, sb.Min.Y+y):]
+		for x, c := range xs {
+			var px [4]float32
+			for i, wt := range c.weights {
+				p := row[(c.first+i)*4:]
+				px[0] += wt * float32(p[0])
+				px[1] += wt * float32(p[1])
+				px[2] += wt * float32(p[2])
+				px[3] += wt * float32(p[3])
+			}
+			copy(tmp[(y*w+x)*4:], px[:])
+		}
+	}
+
+	for y, c := range ys {
+		for x := 0; x < w; x++ {
+			var px [4]float32
+			for i, wt := range c.weights {
+				p := tmp[((c.first+i)*w+x)*4:]
+				px[0] += wt * p[0]
+				px[1] += wt * p[1]
+				px[2] += wt * p[2]
+				px[3] += wt * p[3]
+			}
+
+			d := dst.Pix[dst.PixOffset(x, y):]
+			a := clamp(px[3])
+			d[3] = a
+			// premultiplied colour can never exceed alpha
+			for i := 0; i < 3; i++ {
+				if d[i] = clamp(px[i]); d[i] > a {
+					d[i] = a
+				}
+			}
+		}
+	}
+
+	return dst
+}
+
+// contrib is the contribution of a run of source pixels, starting at first,
+// to a single destination pixel
+type contrib struct {
+	first   int
+	weights []float32
+}
+
+// boxWeights returns, for each of the dst pixels along an axis, the
+// contributions of the src pixels it covers, weighted by the overlap
+func boxWeights(src, dst int) []contrib {
+	res := make([]contrib, dst)
+	scale := float64(src) / float64(dst)
+
+	for i := range res {
+		lo, hi := float64(i)*scale, float64(i+1)*scale
+
+		first := int(lo)
+		last := int(math.Ceil(hi))
+		if last > src {
+			last = src
+		}
+
+		c := contrib{first: first}
+		for j := first; j < last; j++ {
+			ov := math.Min(hi, float64(j+1)) - math.Max(lo, float64(j))
+			c.weights = append(c.weights, float32(ov/scale))
+		}
+
+		res[i] = c
+	}
+
+	return res
+}
+
+// catmullRomWeights returns, for each of the dst pixels along an axis, the
+// contributions of the src pixels within the support of the Catmull-Rom
+// filter centred on it. Pixels beyond the edges are treated as copies of the
+// edge pixels.
+func catmullRomWeights(src, dst int) []contrib {
+	res := make([]contrib, dst)
+	scale := float64(src) / float64(dst)
+
+	// when shrinking, widen the filter to cover every source pixel
+	fs := math.Max(scale, 1)
+	support := 2 * fs
+
+	for i := range res {
+		centre := (float64(i)+0.5)*scale - 0.5
+
+		lo := int(math.Ceil(centre - support))
+		hi := int(math.Floor(centre + support))
+
+		first, last := clampInt(lo, 0, src-1), clampInt(hi, 0, src-1)
+
+		ws := make([]float64, last-first+1)
+		var sum float64
+
+		for j := lo; j <= hi; j++ {
+			wt := catmullRom((float64(j) - centre) / fs)
+			ws[clampInt(j, 0, src-1)-first] += wt
+			sum += wt
+		}
+
+		c := contrib{first: first}
+		for _, wt := range ws {
+			c.weights = append(c.weights, float32(wt/sum))
+		}
+
+		res[i] = c
+	}
+
+	return res
+}
+
+// catmullRom is the Catmull-Rom kernel, the cubic with B = 0 and C = 0.5
+func catmullRom(x float64) float64 {
+	x = math.Abs(x)
+
+	switch {
+	case x < 1:
+		return (1.5*x-2.5)*x*x + 1
+	case x < 2:
+		return ((-0.5*x+2.5)*x-4)*x + 2
+	}
+
+	return 0
+}
+
+func clampInt(v, lo, hi int) int {
+	switch {
+	case v < lo:
+		return lo
+	case v > hi:
+		return hi
+	}
+	return v
+}
+
+func clamp(v float32) uint8 {
+	switch {
+	case v <= 0:
+		return 0
+	case v >= 255:
+		return 255
+	}
+	return uint8(v + 0.5)
+}
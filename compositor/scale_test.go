@@ -0,0 +1,153 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package compositor
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestFit(t *testing.T) {
+	tests := []struct {
+		b    image.Rectangle
+		size int
+		want image.Point
+	}{
+		{image.Rect(0, 0, 100, 100), 32, image.Pt(32, 32)},
+		{image.Rect(0, 0, 200, 100), 32, image.Pt(32, 16)},
+		{image.Rect(0, 0, 100, 200), 32, image.Pt(16, 32)},
+		{image.Rect(0, 0, 1256, 1369), 96, image.Pt(88, 96)},
+		{image.Rect(50, 50, 150, 250), 32, image.Pt(16, 32)},
+		{image.Rect(0, 0, 30, 10), 512, image.Pt(512, 171)},
+		{image.Rect(0, 0, 1000, 1), 32, image.Pt(32, 1)},
+		{image.Rect(0, 0, 0, 10), 32, image.Pt(0, 0)},
+	}
+
+	for _, tc := range tests {
+		if got := Fit(tc.b, tc.size); got != tc.want {
+			t.Errorf("Fit(%v, %v) = %v; want %v", tc.b, tc.size, got, tc.want)
+		}
+	}
+}
+
+func TestParseFilter(t *testing.T) {
+	for _, f := range []Filter{CatmullRom, Box} {
+		got, err := ParseFilter(f.String())
+		if err != nil || got != f {
+			t.Errorf("ParseFilter(%q) = %v, %v; want %v", f.String(), got, err, f)
+		}
+	}
+
+	if _, err := ParseFilter("nearest"); err == nil {
+		t.Errorf("ParseFilter(%q) succeeded", "nearest")
+	}
+}
+
+func TestWeights(t *testing.T) {
+	filters := []struct {
+		name    string
+		weights func(src, dst int) []contrib
+	}{
+		{"box", boxWeights},
+		{"catmullrom", catmullRomWeights},
+	}
+
+	sizes := [][2]int{{10, 10}, {10, 3}, {1369, 96}, {3, 10}, {7, 1}}
+
+	for _, f := range filters {
+		for _, sz := range sizes {
+			src, dst := sz[0], sz[1]
+
+			cs := f.weights(src, dst)
+			if len(cs) != dst {
+				t.Fatalf("%v(%v, %v) has %v contributions; want %v", f.name, src, dst, len(cs), dst)
+			}
+
+			for i, c := range cs {
+				// every destination pixel is a weighted average of source
+				// pixels that exist
+				if c.first < 0 || c.first+len(c.weights) > src {
+					t.Fatalf("%v(%v, %v)[%v] covers [%v, %v), outside the source", f.name, src, dst, i, c.first, c.first+len(c.weights))
+				}
+
+				var sum float64
+				for _, w := range c.weights {
+					sum += float64(w)
+				}
+				if math.Abs(sum-1) > 1e-5 {
+					t.Fatalf("%v(%v, %v)[%v] weights sum to %v; want 1", f.name, src, dst, i, sum)
+				}
+			}
+		}
+	}
+}
+
+func TestBoxWeights(t *testing.T) {
+	// halving averages pairs of pixels
+	for i, c := range boxWeights(4, 2) {
+		if c.first != 2*i || len(c.weights) != 2 || c.weights[0] != 0.5 || c.weights[1] != 0.5 {
+			t.Errorf("boxWeights(4, 2)[%v] = %+v; want pixels %v and %v weighted 0.5", i, c, 2*i, 2*i+1)
+		}
+	}
+
+	// the middle of three pixels is split between two
+	cs := boxWeights(3, 2)
+	if c := cs[0]; c.first != 0 || len(c.weights) != 2 || math.Abs(float64(c.weights[1])-1.0/3) > 1e-6 {
+		t.Errorf("boxWeights(3, 2)[0] = %+v; want pixel 1 weighted 1/3", c)
+	}
+}
+
+func TestCatmullRomWeights(t *testing.T) {
+	// at the same size, each pixel is copied exactly
+	for i, c := range catmullRomWeights(5, 5) {
+		for j, w := range c.weights {
+			want := float32(0)
+			if c.first+j == i {
+				want = 1
+			}
+			if math.Abs(float64(w-want)) > 1e-6 {
+				t.Fatalf("catmullRomWeights(5, 5)[%v] = %+v; want only pixel %v", i, c, i)
+			}
+		}
+	}
+
+	// the kernel interpolates: 1 at 0, 0 at the other integers
+	for _, x := range []float64{-2, -1, 1, 2, 3} {
+		if k := catmullRom(x); k != 0 {
+			t.Errorf("catmullRom(%v) = %v; want 0", x, k)
+		}
+	}
+	if k := catmullRom(0); k != 1 {
+		t.Errorf("catmullRom(0) = %v; want 1", k)
+	}
+}
+
+func TestScale(t *testing.T) {
+	// a uniform image stays uniform whatever the filter and size
+	src := image.NewRGBA(image.Rect(0, 0, 9, 7))
+	c := color.RGBA{R: 0x40, G: 0x20, B: 0x10, A: 0x80}
+	for y := 0; y < 7; y++ {
+		for x := 0; x < 9; x++ {
+			src.SetRGBA(x, y, c)
+		}
+	}
+
+	for _, f := range []Filter{CatmullRom, Box} {
+		for _, sz := range []image.Point{{3, 2}, {9, 7}, {20, 15}} {
+			dst := Scale(src, sz.X, sz.Y, f)
+			if !dst.Rect.Eq(image.Rectangle{Max: sz}) {
+				t.Fatalf("Scale(%v, %v) has bounds %v", sz, f, dst.Rect)
+			}
+			for y := 0; y < sz.Y; y++ {
+				for x := 0; x < sz.X; x++ {
+					if got := dst.RGBAAt(x, y); got != c {
+						t.Fatalf("Scale(%v, %v) pixel (%v, %v) is %v; want %v", sz, f, x, y, got, c)
+					}
+				}
+			}
+		}
+	}
+}
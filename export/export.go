@@ -0,0 +1,31 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+// Package export writes composited gophers in formats other than a single
+// PNG image, e.g. favicons and icon sets.
+//
+// Every function takes the full-size gopher, as returned by
+// compositor.Compositor.Composite, and pads and scales it as each format
+// requires.
+package export
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+
+	"github.com/myitcv/gopherize.me/compositor"
+)
+
+// square returns src padded to a square and scaled to size x size
+func square(src image.Image, size int, f compositor.Filter) *image.RGBA {
+	return compositor.Scale(compositor.Square(src), size, size, f)
+}
+
+func encodePNG(img image.Image) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := png.Encode(buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package export
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"io"
+
+	"github.com/myitcv/gopherize.me/compositor"
+)
+
+// FaviconSizes are the sizes of the images in a favicon written by ICO
+var FaviconSizes = []int{16, 32, 48, 64}
+
+const (
+	icoHeaderLen = 6
+	icoEntryLen  = 16
+
+	// icoMaxSize is the largest size an ICO entry can describe; 256 is
+	// written as 0
+	icoMaxSize = 256
+)
+
+// ICO writes src to w as a Windows icon containing one square,
+// PNG-compressed image for each of sizes
+func ICO(w io.Writer, src image.Image, sizes []int, f compositor.Filter) error {
+	if len(sizes) == 0 {
+		return fmt.Errorf("no icon sizes given")
+	}
+
+	var pngs [][]byte
+
+	for _, s := range sizes {
+		if s < 1 || s > icoMaxSize {
+			return fmt.Errorf("invalid icon size %v; must be between 1 and %v", s, icoMaxSize)
+		}
+
+		b, err := encodePNG(square(src, s, f))
+		if err != nil {
+			return fmt.Errorf("could not encode %vx%v icon: %v", s, s, err)
+		}
+		pngs = append(pngs, b)
+	}
+
+	buf := new(bytes.Buffer)
+
+	// ICONDIR: reserved, type (1 is icon), count
+	binary.Write(buf, binary.LittleEndian, [3]uint16{0, 1, uint16(len(sizes))})
+
+	off := icoHeaderLen + icoEntryLen*len(sizes)
+
+	for i, s := range sizes {
+		// ICONDIRENTRY
+		binary.Write(buf, binary.LittleEndian, struct {
+			Width, Height        uint8
+			Colours, Reserved    uint8
+			Planes, BitsPerPixel uint16
+			Len, Offset          uint32
+		}{
+			Width:        uint8(s % icoMaxSize),
+			Height:       uint8(s % icoMaxSize),
+			Planes:       1,
+			BitsPerPixel: 32,
+			Len:          uint32(len(pngs[i])),
+			Offset:       uint32(off),
+		})
+
+		off += len(pngs[i])
+	}
+
+	for _, b := range pngs {
+		buf.Write(b)
+	}
+
+	_, err := buf.WriteTo(w)
+	return err
+}
@@ -0,0 +1,173 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package export_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"image"
+	"image/color"
+	"image/png"
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/myitcv/gopherize.me/compositor"
+	"github.com/myitcv/gopherize.me/export"
+)
+
+// testImage returns a w x h image with a red square in it
+func testImage(w, h int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := h / 4; y < 3*h/4; y++ {
+		for x := w / 4; x < 3*w/4; x++ {
+			img.SetRGBA(x, y, color.RGBA{R: 0xff, A: 0xff})
+		}
+	}
+	return img
+}
+
+// icoEntry is an ICONDIRENTRY
+type icoEntry struct {
+	Width, Height        uint8
+	Colours, Reserved    uint8
+	Planes, BitsPerPixel uint16
+	Len, Offset          uint32
+}
+
+func TestICO(t *testing.T) {
+	sizes := []int{16, 32, 48, 256}
+
+	buf := new(bytes.Buffer)
+	if err := export.ICO(buf, testImage(100, 60), sizes, compositor.CatmullRom); err != nil {
+		t.Fatal(err)
+	}
+	b := buf.Bytes()
+
+	// ICONDIR
+	var dir [3]uint16
+	if err := binary.Read(bytes.NewReader(b), binary.LittleEndian, &dir); err != nil {
+		t.Fatal(err)
+	}
+	if want := [3]uint16{0, 1, uint16(len(sizes))}; dir != want {
+		t.Fatalf("got ICONDIR %v; want %v", dir, want)
+	}
+
+	entries := make([]icoEntry, len(sizes))
+	if err := binary.Read(bytes.NewReader(b[6:]), binary.LittleEndian, entries); err != nil {
+		t.Fatal(err)
+	}
+
+	// the images follow the directory, one after the other
+	off := 6 + 16*len(sizes)
+
+	for i, e := range entries {
+		s := sizes[i]
+
+		// 256 is written as 0
+		want := icoEntry{
+			Width:        uint8(s % 256),
+			Height:       uint8(s % 256),
+			Planes:       1,
+			BitsPerPixel: 32,
+			Len:          e.Len,
+			Offset:       uint32(off),
+		}
+		if e != want {
+			t.Fatalf("entry %v is %+v; want %+v", i, e, want)
+		}
+
+		img, err := png.Decode(bytes.NewReader(b[e.Offset : e.Offset+e.Len]))
+		if err != nil {
+			t.Fatalf("could not decode entry %v: %v", i, err)
+		}
+		if bs := img.Bounds(); bs.Dx() != s || bs.Dy() != s {
+			t.Fatalf("entry %v is %vx%v; want %vx%v", i, bs.Dx(), bs.Dy(), s, s)
+		}
+
+		off += int(e.Len)
+	}
+
+	if off != len(b) {
+		t.Fatalf("images end at %v; file is %v bytes", off, len(b))
+	}
+}
+
+func TestICOErrors(t *testing.T) {
+	for _, sizes := range [][]int{nil, {0}, {16, 257}} {
+		if err := export.ICO(ioutil.Discard, testImage(10, 10), sizes, compositor.CatmullRom); err == nil {
+			t.Errorf("ICO() with sizes %v succeeded", sizes)
+		}
+	}
+}
+
+func TestIconSet(t *testing.T) {
+	buf := new(bytes.Buffer)
+	if err := export.IconSet(buf, testImage(100, 60), compositor.Box); err != nil {
+		t.Fatal(err)
+	}
+
+	z, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := make(map[string][]byte)
+	for _, f := range z.File {
+		r, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := ioutil.ReadAll(r)
+		r.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[f.Name] = b
+	}
+
+	if len(files) != len(export.Icons)+2 {
+		t.Errorf("got %v files; want favicon.ico, site.webmanifest and %v icons", len(files), len(export.Icons))
+	}
+
+	if _, ok := files["favicon.ico"]; !ok {
+		t.Errorf("no favicon.ico")
+	}
+
+	var wantIcons []string
+	for _, i := range export.Icons {
+		img, err := png.Decode(bytes.NewReader(files[i.Name]))
+		if err != nil {
+			t.Errorf("could not decode %v: %v", i.Name, err)
+			continue
+		}
+		if bs := img.Bounds(); bs.Dx() != i.Size || bs.Dy() != i.Size {
+			t.Errorf("%v is %vx%v; want %vx%v", i.Name, bs.Dx(), bs.Dy(), i.Size, i.Size)
+		}
+		if i.Manifest {
+			wantIcons = append(wantIcons, "/"+i.Name)
+		}
+	}
+
+	var wm struct {
+		Icons []struct {
+			Src   string `json:"src"`
+			Sizes string `json:"sizes"`
+			Type  string `json:"type"`
+		} `json:"icons"`
+	}
+	if err := json.Unmarshal(files["site.webmanifest"], &wm); err != nil {
+		t.Fatalf("could not parse site.webmanifest: %v", err)
+	}
+
+	var gotIcons []string
+	for _, i := range wm.Icons {
+		gotIcons = append(gotIcons, i.Src)
+	}
+	if !reflect.DeepEqual(gotIcons, wantIcons) {
+		t.Errorf("site.webmanifest lists %v; want %v", gotIcons, wantIcons)
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"image"
+	"io"
+
+	"github.com/myitcv/gopherize.me/compositor"
+)
+
+// Icon is a single image in an icon set
+type Icon struct {
+	Name string
+	Size int
+
+	// Manifest is whether the icon is listed in site.webmanifest
+	Manifest bool
+}
+
+// Icons are the images written by IconSet, in addition to favicon.ico
+var Icons = []Icon{
+	{Name: "favicon-16x16.png", Size: 16},
+	{Name: "favicon-32x32.png", Size: 32},
+	{Name: "apple-touch-icon.png", Size: 180},
+	{Name: "android-chrome-192x192.png", Size: 192, Manifest: true},
+	{Name: "android-chrome-512x512.png", Size: 512, Manifest: true},
+}
+
+const (
+	faviconName     = "favicon.ico"
+	webManifestName = "site.webmanifest"
+)
+
+// webManifestIcon is an entry in the icons member of a web app manifest
+type webManifestIcon struct {
+	Src   string `json:"src"`
+	Sizes string `json:"sizes"`
+	Type  string `json:"type"`
+}
+
+// IconSet writes to w a zip of src as favicon.ico, the Icons and a
+// site.webmanifest fragment listing the icons for a web app manifest
+func IconSet(w io.Writer, src image.Image, f compositor.Filter) error {
+	z := zip.NewWriter(w)
+
+	buf := new(bytes.Buffer)
+	if err := ICO(buf, src, FaviconSizes, f); err != nil {
+		return err
+	}
+	if err := writeZip(z, faviconName, buf.Bytes()); err != nil {
+		return err
+	}
+
+	// the fragment is a complete manifest with only the icons member, so
+	// that it can be merged into an existing manifest
+	var wm struct {
+		Icons []webManifestIcon `json:"icons"`
+	}
+
+	for _, i := range Icons {
+		b, err := encodePNG(square(src, i.Size, f))
+		if err != nil {
+			return fmt.Errorf("could not encode %v: %v", i.Name, err)
+		}
+		if err := writeZip(z, i.Name, b); err != nil {
+			return err
+		}
+
+		if i.Manifest {
+			wm.Icons = append(wm.Icons, webManifestIcon{
+				Src:   "/" + i.Name,
+				Sizes: fmt.Sprintf("%vx%v", i.Size, i.Size),
+				Type:  "image/png",
+			})
+		}
+	}
+
+	b, err := json.MarshalIndent(wm, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeZip(z, webManifestName, append(b, '\n')); err != nil {
+		return err
+	}
+
+	return z.Close()
+}
+
+func writeZip(z *zip.Writer, name string, b []byte) error {
+	fw, err := z.Create(name)
+	if err != nil {
+		return fmt.Errorf("could not add %v to zip: %v", name, err)
+	}
+
+	if _, err := fw.Write(b); err != nil {
+		return fmt.Errorf("could not write %v to zip: %v", name, err)
+	}
+
+	return nil
+}
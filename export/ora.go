@@ -0,0 +1,154 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package export
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"path"
+
+	"github.com/myitcv/gopherize.me/compositor"
+)
+
+const (
+	oraMimeType = "image/openraster"
+
+	// oraThumbnailSize is the largest size of an OpenRaster thumbnail
+	oraThumbnailSize = 256
+
+	oraBackgroundName = "Background"
+)
+
+// oraImage is the root element of an OpenRaster stack.xml
+type oraImage struct {
+	XMLName xml.Name `xml:"image"`
+	Version string   `xml:"version,attr"`
+	W       int      `xml:"w,attr"`
+	H       int      `xml:"h,attr"`
+	Stack   oraStack `xml:"stack"`
+}
+
+type oraStack struct {
+	Layers []oraLayer `xml:"layer"`
+}
+
+type oraLayer struct {
+	Name       string  `xml:"name,attr"`
+	Src        string  `xml:"src,attr"`
+	X          int     `xml:"x,attr"`
+	Y          int     `xml:"y,attr"`
+	Opacity    float64 `xml:"opacity,attr"`
+	Visibility string  `xml:"visibility,attr"`
+}
+
+// ORA writes ls, in drawing order as returned by compositor.Compositor.Layers,
+// to w as an OpenRaster image with one layer per option. If bg is not nil
+// a background layer filled with bg is added beneath the others. The merged
+// image and thumbnail are the flattened gopher.
+func ORA(w io.Writer, ls []compositor.Layer, bg color.Color) error {
+	if len(ls) == 0 {
+		return fmt.Errorf("no layers to export")
+	}
+
+	merged := compositor.Flatten(ls)
+	b := merged.Bounds()
+
+	type entry struct {
+		layer oraLayer
+		img   image.Image
+	}
+
+	var entries []entry
+
+	if bg != nil {
+		entries = append(entries, entry{
+			layer: oraLayer{Name: oraBackgroundName, Src: "data/background.png"},
+			img:   compositor.Background(image.NewRGBA(b), bg),
+		})
+		merged = compositor.Background(merged, bg)
+	}
+
+	for _, l := range ls {
+		off := l.Image.Bounds().Min.Sub(b.Min)
+		entries = append(entries, entry{
+			layer: oraLayer{
+				Name: l.Category.Name + ": " + l.Option.Name,
+				Src:  path.Join("data", l.ID()+".png"),
+				X:    off.X,
+				Y:    off.Y,
+			},
+			img: l.Image,
+		})
+	}
+
+	z := zip.NewWriter(w)
+
+	// the mimetype must come first, uncompressed
+	mw, err := z.CreateHeader(&zip.FileHeader{
+		Name:   "mimetype",
+		Method: zip.Store,
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(mw, oraMimeType); err != nil {
+		return err
+	}
+
+	doc := oraImage{
+		Version: "0.0.3",
+		W:       b.Dx(),
+		H:       b.Dy(),
+	}
+
+	// the stack lists the topmost layer first
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		e.layer.Opacity = 1
+		e.layer.Visibility = "visible"
+		doc.Stack.Layers = append(doc.Stack.Layers, e.layer)
+
+		p, err := encodePNG(e.img)
+		if err != nil {
+			return fmt.Errorf("could not encode layer %v: %v", e.layer.Name, err)
+		}
+		if err := writeZip(z, e.layer.Src, p); err != nil {
+			return err
+		}
+	}
+
+	x, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeZip(z, "stack.xml", append([]byte(xml.Header), x...)); err != nil {
+		return err
+	}
+
+	p, err := encodePNG(merged)
+	if err != nil {
+		return fmt.Errorf("could not encode merged image: %v", err)
+	}
+	if err := writeZip(z, "mergedimage.png", p); err != nil {
+		return err
+	}
+
+	tb := merged.Bounds()
+	if tb.Dx() > oraThumbnailSize || tb.Dy() > oraThumbnailSize {
+		sz := compositor.Fit(tb, oraThumbnailSize)
+		p, err = encodePNG(compositor.Scale(merged, sz.X, sz.Y, compositor.CatmullRom))
+		if err != nil {
+			return fmt.Errorf("could not encode thumbnail: %v", err)
+		}
+	}
+	if err := writeZip(z, "Thumbnails/thumbnail.png", p); err != nil {
+		return err
+	}
+
+	return z.Close()
+}
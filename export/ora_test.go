@@ -0,0 +1,156 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package export_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"image"
+	"image/color"
+	"image/png"
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/myitcv/gopherize.me/compositor"
+	"github.com/myitcv/gopherize.me/export"
+	"github.com/myitcv/gopherize.me/gopher"
+)
+
+// testLayers returns a body and a smaller pair of eyes drawn over it, in
+// drawing order
+func testLayers() []compositor.Layer {
+	fill := func(r image.Rectangle, c color.RGBA) *image.RGBA {
+		img := image.NewRGBA(r)
+		for y := r.Min.Y; y < r.Max.Y; y++ {
+			for x := r.Min.X; x < r.Max.X; x++ {
+				img.SetRGBA(x, y, c)
+			}
+		}
+		return img
+	}
+
+	body := &gopher.Category{ID: "body", Name: "Body"}
+	eyes := &gopher.Category{ID: "eyes", Name: "Eyes"}
+
+	return []compositor.Layer{
+		{
+			Layer: gopher.Layer{Category: body, Option: &gopher.Option{ID: "blue_gopher", Name: "Blue Gopher"}},
+			Image: fill(image.Rect(0, 0, 8, 6), color.RGBA{R: 0xab, G: 0xc3, B: 0xd6, A: 0xff}),
+		},
+		{
+			Layer: gopher.Layer{Category: eyes, Option: &gopher.Option{ID: "eyes", Name: "Eyes"}},
+			Image: fill(image.Rect(2, 1, 6, 3), color.RGBA{A: 0xff}),
+		},
+	}
+}
+
+func TestORA(t *testing.T) {
+	buf := new(bytes.Buffer)
+	if err := export.ORA(buf, testLayers(), color.White); err != nil {
+		t.Fatal(err)
+	}
+
+	z, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the mimetype comes first, stored, so that the format can be detected
+	// from a fixed offset
+	mt := z.File[0]
+	if mt.Name != "mimetype" || mt.Method != zip.Store {
+		t.Fatalf("first file is %v, method %v; want mimetype, stored", mt.Name, mt.Method)
+	}
+	if got := string(readZip(t, mt)); got != "image/openraster" {
+		t.Fatalf("mimetype is %q; want %q", got, "image/openraster")
+	}
+
+	files := make(map[string]*zip.File)
+	for _, f := range z.File {
+		files[f.Name] = f
+	}
+
+	sf, ok := files["stack.xml"]
+	if !ok {
+		t.Fatalf("no stack.xml")
+	}
+
+	var stack struct {
+		W      int `xml:"w,attr"`
+		H      int `xml:"h,attr"`
+		Layers []struct {
+			Name string `xml:"name,attr"`
+			Src  string `xml:"src,attr"`
+			X    int    `xml:"x,attr"`
+			Y    int    `xml:"y,attr"`
+		} `xml:"stack>layer"`
+	}
+	if err := xml.Unmarshal(readZip(t, sf), &stack); err != nil {
+		t.Fatalf("could not parse stack.xml: %v", err)
+	}
+
+	if stack.W != 8 || stack.H != 6 {
+		t.Errorf("stack.xml is %vx%v; want 8x6", stack.W, stack.H)
+	}
+
+	// the topmost layer is listed first
+	var names, srcs []string
+	for _, l := range stack.Layers {
+		names = append(names, l.Name)
+		srcs = append(srcs, l.Src)
+	}
+	if want := []string{"Eyes: Eyes", "Body: Blue Gopher", "Background"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("stack.xml lists layers %v; want %v", names, want)
+	}
+	if want := []string{"data/eyes-eyes.png", "data/body-blue_gopher.png", "data/background.png"}; !reflect.DeepEqual(srcs, want) {
+		t.Errorf("stack.xml lists sources %v; want %v", srcs, want)
+	}
+	if l := stack.Layers[0]; l.X != 2 || l.Y != 1 {
+		t.Errorf("eyes are at (%v, %v); want (2, 1)", l.X, l.Y)
+	}
+
+	for _, src := range append(srcs, "mergedimage.png", "Thumbnails/thumbnail.png") {
+		f, ok := files[src]
+		if !ok {
+			t.Errorf("no %v", src)
+			continue
+		}
+		if _, err := png.Decode(bytes.NewReader(readZip(t, f))); err != nil {
+			t.Errorf("could not decode %v: %v", src, err)
+		}
+	}
+
+	merged, err := png.Decode(bytes.NewReader(readZip(t, files["mergedimage.png"])))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c := color.RGBAModel.Convert(merged.At(3, 2)); c != (color.RGBA{A: 0xff}) {
+		t.Errorf("merged image is %v at (3, 2); want the eyes", c)
+	}
+}
+
+func TestORANoLayers(t *testing.T) {
+	if err := export.ORA(ioutil.Discard, nil, nil); err == nil {
+		t.Fatalf("ORA() of no layers succeeded")
+	}
+}
+
+func readZip(t *testing.T, f *zip.File) []byte {
+	t.Helper()
+
+	r, err := f.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return b
+}
@@ -0,0 +1,33 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package export
+
+import (
+	"image"
+	"io"
+
+	"github.com/myitcv/gopherize.me/gopher"
+	"github.com/myitcv/gopherize.me/pngmeta"
+)
+
+// PNG writes img to w as a PNG image, embedding r, the recipe of the gopher,
+// and version, the version of the artwork from which it was rendered, so that
+// pngmeta.ReadRecipe can read them back
+func PNG(w io.Writer, img image.Image, r gopher.Recipe, version string) error {
+	p, err := encodePNG(img)
+	if err != nil {
+		return err
+	}
+
+	p, err = pngmeta.Insert(p, map[string]string{
+		pngmeta.RecipeKey:         r.Encode(),
+		pngmeta.ArtworkVersionKey: version,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(p)
+	return err
+}
@@ -0,0 +1,173 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package export
+
+import (
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"image/color"
+	"io"
+	"strings"
+
+	"github.com/myitcv/gopherize.me/compositor"
+	"github.com/myitcv/gopherize.me/gopher"
+)
+
+const (
+	svgNS = "http://www.w3.org/2000/svg"
+
+	// RecipeNS is the XML namespace of the recipe element written into the
+	// metadata of an SVG
+	RecipeNS = "https://gopherize.me/ns/recipe"
+)
+
+type svgDoc struct {
+	XMLName  xml.Name    `xml:"svg"`
+	NS       string      `xml:"xmlns,attr"`
+	Width    int         `xml:"width,attr"`
+	Height   int         `xml:"height,attr"`
+	ViewBox  string      `xml:"viewBox,attr"`
+	Metadata svgMetadata `xml:"metadata"`
+	Rect     *svgRect    `xml:"rect"`
+	Groups   []svgGroup  `xml:"g"`
+}
+
+type svgMetadata struct {
+	Recipe svgRecipe `xml:"recipe"`
+}
+
+type svgRecipe struct {
+	NS    string `xml:"xmlns,attr"`
+	Value string `xml:",chardata"`
+}
+
+type svgRect struct {
+	Width       string  `xml:"width,attr"`
+	Height      string  `xml:"height,attr"`
+	Fill        string  `xml:"fill,attr"`
+	FillOpacity float64 `xml:"fill-opacity,attr"`
+}
+
+type svgGroup struct {
+	ID    string   `xml:"id,attr"`
+	Title string   `xml:"title"`
+	Image svgImage `xml:"image"`
+}
+
+type svgImage struct {
+	X      int    `xml:"x,attr"`
+	Y      int    `xml:"y,attr"`
+	Width  int    `xml:"width,attr"`
+	Height int    `xml:"height,attr"`
+	Href   string `xml:"href,attr"`
+}
+
+// SVG writes ls, in drawing order as returned by compositor.Compositor.Layers,
+// to w as an SVG image with one group per option, each embedding its layer
+// as a PNG. r, the recipe of the gopher, is written into the metadata of the
+// SVG, from where SVGRecipe can read it. If bg is not nil the background is
+// filled with bg.
+func SVG(w io.Writer, ls []compositor.Layer, r gopher.Recipe, bg color.Color) error {
+	if len(ls) == 0 {
+		return fmt.Errorf("no layers to export")
+	}
+
+	b := ls[0].Image.Bounds()
+	for _, l := range ls {
+		b = b.Union(l.Image.Bounds())
+	}
+
+	doc := svgDoc{
+		NS:      svgNS,
+		Width:   b.Dx(),
+		Height:  b.Dy(),
+		ViewBox: fmt.Sprintf("0 0 %v %v", b.Dx(), b.Dy()),
+		Metadata: svgMetadata{
+			Recipe: svgRecipe{NS: RecipeNS, Value: r.Encode()},
+		},
+	}
+
+	if bg != nil {
+		c := color.NRGBAModel.Convert(bg).(color.NRGBA)
+		doc.Rect = &svgRect{
+			Width:       "100%",
+			Height:      "100%",
+			Fill:        fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B),
+			FillOpacity: float64(c.A) / 255,
+		}
+	}
+
+	for _, l := range ls {
+		p, err := encodePNG(l.Image)
+		if err != nil {
+			return fmt.Errorf("could not encode layer %v: %v", l.ID(), err)
+		}
+
+		lb := l.Image.Bounds()
+		off := lb.Min.Sub(b.Min)
+
+		doc.Groups = append(doc.Groups, svgGroup{
+			ID:    l.ID(),
+			Title: l.Category.Name + ": " + l.Option.Name,
+			Image: svgImage{
+				X:      off.X,
+				Y:      off.Y,
+				Width:  lb.Dx(),
+				Height: lb.Dy(),
+				Href:   "data:image/png;base64," + base64.StdEncoding.EncodeToString(p),
+			},
+		})
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// SVGRecipe reads the recipe from the metadata of an SVG written by SVG. It
+// checks only the form of the recipe; use gopher.Recipe.Validate to check it
+// against a manifest.
+func SVGRecipe(rd io.Reader) (gopher.Recipe, error) {
+	dec := xml.NewDecoder(rd)
+
+	inMetadata := false
+
+	for {
+		t, err := dec.Token()
+		if err == io.EOF {
+			return nil, fmt.Errorf("no recipe found in SVG")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not parse SVG: %v", err)
+		}
+
+		switch t := t.(type) {
+		case xml.StartElement:
+			switch {
+			case t.Name.Local == "metadata":
+				inMetadata = true
+			case inMetadata && t.Name.Space == RecipeNS && t.Name.Local == "recipe":
+				var v string
+				if err := dec.DecodeElement(&v, &t); err != nil {
+					return nil, fmt.Errorf("could not parse recipe in SVG: %v", err)
+				}
+				return gopher.Decode(strings.TrimSpace(v))
+			}
+		case xml.EndElement:
+			if t.Name.Local == "metadata" {
+				inMetadata = false
+			}
+		}
+	}
+}
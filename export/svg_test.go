@@ -0,0 +1,126 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package export_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"image/color"
+	"image/png"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/myitcv/gopherize.me/export"
+	"github.com/myitcv/gopherize.me/gopher"
+)
+
+func TestSVGRecipe(t *testing.T) {
+	tests := []struct {
+		name string
+		bg   color.Color
+	}{
+		{"transparent", nil},
+		{"background", color.NRGBA{R: 0xff, G: 0x88, A: 0x80}},
+	}
+
+	r := gopher.Recipe{"body": {"blue_gopher"}, "eyes": {"eyes"}, gopher.ColourKey: {"ff8800"}}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			if err := export.SVG(buf, testLayers(), r, tc.bg); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := export.SVGRecipe(bytes.NewReader(buf.Bytes()))
+			if err != nil {
+				t.Fatalf("SVGRecipe() failed: %v", err)
+			}
+			if !reflect.DeepEqual(got, r) {
+				t.Fatalf("SVGRecipe() = %v; want %v", got, r)
+			}
+		})
+	}
+}
+
+func TestSVG(t *testing.T) {
+	buf := new(bytes.Buffer)
+	if err := export.SVG(buf, testLayers(), gopher.Recipe{"body": {"blue_gopher"}}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	var doc struct {
+		Width  int `xml:"width,attr"`
+		Height int `xml:"height,attr"`
+		Groups []struct {
+			ID    string `xml:"id,attr"`
+			Image struct {
+				X    int    `xml:"x,attr"`
+				Y    int    `xml:"y,attr"`
+				Href string `xml:"href,attr"`
+			} `xml:"image"`
+		} `xml:"g"`
+	}
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("could not parse SVG: %v", err)
+	}
+
+	if doc.Width != 8 || doc.Height != 6 {
+		t.Errorf("SVG is %vx%v; want 8x6", doc.Width, doc.Height)
+	}
+
+	// groups are in drawing order
+	var ids []string
+	for _, g := range doc.Groups {
+		ids = append(ids, g.ID)
+	}
+	if want := []string{"body-blue_gopher", "eyes-eyes"}; !reflect.DeepEqual(ids, want) {
+		t.Fatalf("SVG has groups %v; want %v", ids, want)
+	}
+
+	eyes := doc.Groups[1].Image
+	if eyes.X != 2 || eyes.Y != 1 {
+		t.Errorf("eyes are at (%v, %v); want (2, 1)", eyes.X, eyes.Y)
+	}
+
+	const prefix = "data:image/png;base64,"
+	if !strings.HasPrefix(eyes.Href, prefix) {
+		t.Fatalf("eyes href begins %.30q; want %q", eyes.Href, prefix)
+	}
+	p, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(eyes.Href, prefix))
+	if err != nil {
+		t.Fatalf("could not decode eyes href: %v", err)
+	}
+	img, err := png.Decode(bytes.NewReader(p))
+	if err != nil {
+		t.Fatalf("could not decode eyes image: %v", err)
+	}
+	if b := img.Bounds(); b.Dx() != 4 || b.Dy() != 2 {
+		t.Errorf("eyes image is %vx%v; want 4x2", b.Dx(), b.Dy())
+	}
+}
+
+func TestSVGRecipeErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		svg  string
+	}{
+		{"no metadata", `<svg xmlns="http://www.w3.org/2000/svg"></svg>`},
+		{"recipe outside metadata", `<svg xmlns="http://www.w3.org/2000/svg"><recipe xmlns="` + export.RecipeNS + `">1.body-blue_gopher</recipe></svg>`},
+		{"other namespace", `<svg xmlns="http://www.w3.org/2000/svg"><metadata><recipe>1.body-blue_gopher</recipe></metadata></svg>`},
+		{"bad recipe", `<svg xmlns="http://www.w3.org/2000/svg"><metadata><recipe xmlns="` + export.RecipeNS + `">1.body</recipe></metadata></svg>`},
+		{"not XML", `<svg`},
+		{"empty", ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if r, err := export.SVGRecipe(strings.NewReader(tc.svg)); err == nil {
+				t.Fatalf("SVGRecipe() = %v; want error", r)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package gopher
+
+import "fmt"
+
+// ColourKey is the key under which a recipe records the colour to which the
+// recolourable parts of a gopher (see Option.Colour) are recoloured. It is
+// encoded like a category, e.g. colour-ff8800, so no category can have it as
+// its ID.
+const ColourKey = "colour"
+
+// Colour returns the colour chosen in r, as six lower-case hex digits, or ""
+// if r keeps the colours of the artwork
+func (r Recipe) Colour() string {
+	if cs := r[ColourKey]; len(cs) > 0 {
+		return cs[0]
+	}
+
+	return ""
+}
+
+// WithColour returns a copy of r with the colour hex, given as six lower-case
+// hex digits, chosen. If hex is empty, the copy keeps the colours of the
+// artwork.
+func (r Recipe) WithColour(hex string) Recipe {
+	res := r.Clone()
+
+	if hex == "" {
+		delete(res, ColourKey)
+	} else {
+		res[ColourKey] = []string{hex}
+	}
+
+	return res
+}
+
+// ValidColour reports whether s is a colour of the form used by Recipe.Colour
+// and Option.Colour
+func ValidColour(s string) bool {
+	if len(s) != 6 {
+		return false
+	}
+
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9', r >= 'a' && r <= 'f':
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+func validateColour(cs []string) error {
+	if len(cs) != 1 || !ValidColour(cs[0]) {
+		return fmt.Errorf("invalid colour %v in recipe; expected %v%vrrggbb", cs, ColourKey, idSep)
+	}
+
+	return nil
+}
+
+// Swatches returns the bodies of m that have a colour, in manifest order,
+// leaving out any with the same colour as an earlier one. The colours of the
+// swatches are the presets offered alongside a free choice of colour.
+func (m *Manifest) Swatches() []*Option {
+	c := m.Category(BodyID)
+	if c == nil {
+		return nil
+	}
+
+	var res []*Option
+	seen := make(map[string]bool)
+
+	for _, o := range c.Options {
+		if o.Colour == "" || seen[o.Colour] {
+			continue
+		}
+		seen[o.Colour] = true
+		res = append(res, o)
+	}
+
+	return res
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package gopher_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/myitcv/gopherize.me/gopher"
+)
+
+func TestSwatches(t *testing.T) {
+	m := &gopher.Manifest{
+		Categories: []*gopher.Category{
+			{ID: gopher.BodyID, Options: []*gopher.Option{
+				{ID: "blue_gopher", Colour: "abc3d6"},
+				{ID: "ghost_gopher"},
+				{ID: "navy_gopher", Colour: "abc3d6"},
+				{ID: "pink_gopher", Colour: "ffcaca"},
+			}},
+			{ID: "hair", Options: []*gopher.Option{
+				{ID: "blue_ears", Colour: "c0e4e3"},
+			}},
+		},
+	}
+
+	var got []string
+	for _, o := range m.Swatches() {
+		got = append(got, o.ID)
+	}
+
+	// only bodies, and only the first with each colour
+	if want := "[blue_gopher pink_gopher]"; fmt.Sprint(got) != want {
+		t.Fatalf("Swatches() = %v; want %v", got, want)
+	}
+}
+
+func TestWithColour(t *testing.T) {
+	r := gopher.Recipe{gopher.BodyID: {"blue_gopher"}}
+
+	c := r.WithColour("ff8800")
+	if c.Colour() != "ff8800" {
+		t.Fatalf("Colour() = %q; want ff8800", c.Colour())
+	}
+	if r.Colour() != "" {
+		t.Fatalf("WithColour changed its receiver to %v", r)
+	}
+
+	if u := c.WithColour(""); u.Colour() != "" || !u.Equals(r) {
+		t.Fatalf("WithColour(\"\") = %v; want %v", u, r)
+	}
+}
@@ -0,0 +1,11 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package gopher
+
+// Default is the recipe for the plain gopher that the app starts with and
+// returns to on reset. It must not be modified; use Clone to obtain a copy.
+var Default = Recipe{
+	"body": {"blue_gopher"},
+	"eyes": {"eyes"},
+}
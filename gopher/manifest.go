@@ -0,0 +1,109 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package gopher
+
+// BodyID is the ID of the category of bodies, on which the other layers of a
+// gopher are drawn
+const BodyID = "body"
+
+// Manifest is the ordered list of categories that make up a gopher
+type Manifest struct {
+	// Version is a digest of the paths and contents of every full-size
+	// image and category metadata file; it changes whenever the rendering of
+	// some gopher might
+	Version string
+
+	Categories []*Category
+
+	// Rules constrain the options that can be combined
+	Rules []*Rule
+}
+
+// Category is a single layer of a gopher, e.g. Shirts
+type Category struct {
+	// ID is the stable identifier of the category, derived from the directory
+	// name without its numeric prefix, e.g. shirts
+	ID string
+
+	// Name is the display name of the category, e.g. Hats and Hair Accessories
+	Name string
+
+	// Dir is the directory containing the category's artwork, e.g. 021-Shirts
+	Dir string
+
+	// Order is the numeric prefix of Dir; categories are drawn in increasing
+	// Order
+	Order int
+
+	// Multi reports whether more than one option can be chosen in the
+	// category at once, e.g. extras
+	Multi bool
+
+	// Options are in the order in which they are drawn, should more than one
+	// be chosen
+	Options []*Option
+}
+
+// Option is a single choice within a category
+type Option struct {
+	// ID is the stable identifier of the option, the file name of the image
+	// without its extension, e.g. blue_gopher
+	ID string
+
+	// Name is the display name of the option, e.g. Blue Gopher. It is
+	// derived from ID unless the category's metadata gives one.
+	Name string
+
+	// Image is the slash-separated path to the full-size image, relative to
+	// the artwork root
+	Image string
+
+	// Thumbnail is the slash-separated path to the thumbnail image, relative
+	// to the artwork root. It is empty if the option has no thumbnail.
+	Thumbnail string
+
+	// Tags are further words by which the option might be searched for, e.g.
+	// the name of the project or person it depicts
+	Tags []string
+
+	// Artist credits the creator of the artwork
+	Artist string
+
+	// Licence is the licence under which the artwork may be used, preferably
+	// as an SPDX identifier, e.g. CC-BY-NC-SA-4.0
+	Licence string
+
+	// Sponsored reports whether the option depicts a sponsor, or is otherwise
+	// someone's trademark
+	Sponsored bool
+
+	// Colour is the key colour, as six lower-case hex digits, in which the
+	// recolourable region of the artwork is drawn, e.g. the fur of a body.
+	// It is empty if the option cannot be recoloured.
+	Colour string
+}
+
+// Category returns the category with the given ID, or nil if there is no such
+// category
+func (m *Manifest) Category(id string) *Category {
+	for _, c := range m.Categories {
+		if c.ID == id {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// Option returns the option with the given ID, or nil if there is no such
+// option
+func (c *Category) Option(id string) *Option {
+	for _, o := range c.Options {
+		if o.ID == id {
+			return o
+		}
+	}
+
+	return nil
+}
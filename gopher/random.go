@@ -0,0 +1,73 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package gopher
+
+import (
+	"math/rand"
+)
+
+// Optional maps the IDs of categories that a gopher can do without to the
+// probability that Random chooses an option from that category. Every other
+// category always has an option chosen.
+var Optional = map[string]float64{
+	"facial_hair":               0.3,
+	"glasses":                   0.3,
+	"hats_and_hair_accessories": 0.3,
+	"extras":                    0.2,
+}
+
+// Random returns a random recipe of the artwork described by m, choosing one
+// option from every category other than those in Optional, which are
+// sometimes left empty. Multi-select categories also have just one option
+// chosen. The recipe follows the preferences of m's rules and
+// breaks none of them. The same seed always returns the same recipe for a
+// given manifest.
+func Random(m *Manifest, seed int64) Recipe {
+	rnd := rand.New(rand.NewSource(seed))
+
+	res := make(Recipe)
+
+	for _, c := range m.Categories {
+		if len(c.Options) == 0 {
+			continue
+		}
+
+		// always draw, so that whether or not an optional category is
+		// included does not depend on the outcome of another
+		pick := rnd.Float64()
+		o := c.Options[rnd.Intn(len(c.Options))]
+
+		if p, ok := Optional[c.ID]; ok && pick >= p {
+			continue
+		}
+
+		res[c.ID] = []string{o.ID}
+	}
+
+	for _, rl := range m.Rules {
+		if !rl.If.In(res) {
+			continue
+		}
+
+		// switch to a preferred option in each category that has an option
+		// chosen, but not one that is preferred
+		prefs := make(map[string][]string)
+		var cats []string
+		for _, f := range rl.Prefers {
+			if _, ok := prefs[f.Category]; !ok {
+				cats = append(cats, f.Category)
+			}
+			prefs[f.Category] = append(prefs[f.Category], f.Option)
+		}
+
+		for _, c := range cats {
+			if _, ok := res[c]; !ok || anyIn(rl.Prefers, Recipe{c: res[c]}) {
+				continue
+			}
+			res[c] = []string{prefs[c][rnd.Intn(len(prefs[c]))]}
+		}
+	}
+
+	return res.Resolve(m, Ref{})
+}
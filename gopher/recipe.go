@@ -0,0 +1,296 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+// Package gopher is the model of a gopher shared by the client, server and
+// command line tools: the manifest of categories and options, the recipe
+// choosing options in each category, and validation and randomisation of
+// recipes.
+//
+// The package deliberately depends on neither the browser nor any image
+// packages, so that it can be compiled with GopherJS as well as the standard
+// Go toolchain.
+package gopher
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// Version is the version of the recipe encoding written by Encode
+	Version = "1"
+
+	layerSep = "."
+	idSep    = "-"
+	optSep   = "~"
+)
+
+// Recipe maps category IDs to the IDs of the options chosen in that
+// category, in ID order. Only multi-select categories (see Category.Multi) can
+// have more than one option chosen. Categories with no chosen option are
+// absent. A recipe can also choose a colour for the gopher; see ColourKey.
+//
+// Recipes refer to categories and options by their IDs, which are derived
+// from directory and file names, so adding artwork does not change the
+// meaning of an existing recipe.
+type Recipe map[string][]string
+
+// Encode returns the canonical, URL-safe encoding of r. The encoding is the
+// version followed by the options chosen in each category, in category ID
+// order, with the options of a multi-select category in ID order separated by
+// ~, e.g.
+//
+//	1.body-blue_gopher.extras-coffee~watch.eyes-eyes
+func (r Recipe) Encode() string {
+	cats := make([]string, 0, len(r))
+	for c, opts := range r {
+		if len(opts) > 0 {
+			cats = append(cats, c)
+		}
+	}
+	sort.Strings(cats)
+
+	parts := []string{Version}
+	for _, c := range cats {
+		parts = append(parts, c+idSep+strings.Join(sorted(r[c]), optSep))
+	}
+
+	return strings.Join(parts, layerSep)
+}
+
+func (r Recipe) String() string {
+	return r.Encode()
+}
+
+// Decode parses a recipe previously encoded by Encode. It checks only the
+// form of s; use Validate to check the recipe against a manifest.
+func Decode(s string) (Recipe, error) {
+	parts := strings.Split(s, layerSep)
+
+	if v := parts[0]; v != Version {
+		return nil, fmt.Errorf("unknown recipe version %q", v)
+	}
+
+	res := make(Recipe)
+
+	for _, p := range parts[1:] {
+		i := strings.Index(p, idSep)
+		if i == -1 {
+			return nil, fmt.Errorf("invalid layer %q in recipe; expected category%voption", p, idSep)
+		}
+
+		c, opts := p[:i], strings.Split(p[i+len(idSep):], optSep)
+
+		if !validID(c) {
+			return nil, fmt.Errorf("invalid layer %q in recipe", p)
+		}
+
+		if _, ok := res[c]; ok {
+			return nil, fmt.Errorf("category %q appears more than once in recipe", c)
+		}
+
+		seen := make(map[string]bool)
+		for _, o := range opts {
+			if !validID(o) {
+				return nil, fmt.Errorf("invalid layer %q in recipe", p)
+			}
+			if seen[o] {
+				return nil, fmt.Errorf("option %q appears more than once in category %q", o, c)
+			}
+			seen[o] = true
+		}
+
+		res[c] = sorted(opts)
+	}
+
+	return res, nil
+}
+
+// Validate checks that every category and option in r exists in m, that only
+// multi-select categories have more than one option chosen, and that any
+// colour is well formed. It does not check r against m's rules, which change
+// as artwork is added: a recipe that was once valid remains so. Recipes from
+// elsewhere, e.g. a link, should instead be made to follow the rules with
+// Resolve, and new recipes checked with CheckRules.
+func (r Recipe) Validate(m *Manifest) error {
+	// a colour alone draws nothing
+	if _, ok := r[ColourKey]; len(r) == 0 || ok && len(r) == 1 {
+		return fmt.Errorf("recipe is empty")
+	}
+
+	for c, opts := range r {
+		if c == ColourKey {
+			if err := validateColour(opts); err != nil {
+				return err
+			}
+			continue
+		}
+
+		cat := m.Category(c)
+		if cat == nil {
+			return fmt.Errorf("unknown category %q", c)
+		}
+
+		if len(opts) > 1 && !cat.Multi {
+			return fmt.Errorf("only one option can be chosen in category %q", c)
+		}
+
+		for _, o := range opts {
+			if cat.Option(o) == nil {
+				return fmt.Errorf("unknown option %q in category %q", o, c)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validID(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for _, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// sorted returns a sorted copy of ss
+func sorted(ss []string) []string {
+	res := append([]string(nil), ss...)
+	sort.Strings(res)
+	return res
+}
+
+// Clone returns a copy of r
+func (r Recipe) Clone() Recipe {
+	res := make(Recipe, len(r))
+	for c, opts := range r {
+		res[c] = append([]string(nil), opts...)
+	}
+	return res
+}
+
+// Equals reports whether r and v choose the same options
+func (r Recipe) Equals(v Recipe) bool {
+	if len(r) != len(v) {
+		return false
+	}
+
+	for c, opts := range r {
+		vopts, ok := v[c]
+		if !ok || len(vopts) != len(opts) {
+			return false
+		}
+		for _, o := range opts {
+			if !v.Has(c, o) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// Has reports whether r chooses opt in category cat
+func (r Recipe) Has(cat, opt string) bool {
+	for _, o := range r[cat] {
+		if o == opt {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Toggle returns a copy of r with opt chosen in category cat, in addition to
+// the options already chosen if cat is a multi-select category of m. If opt
+// is already chosen in cat, the copy does not choose it instead. If opt is
+// empty, the copy has no option chosen in cat.
+func (r Recipe) Toggle(m *Manifest, cat, opt string) Recipe {
+	res := r.Clone()
+
+	switch {
+	case opt == "":
+		delete(res, cat)
+	case res.Has(cat, opt):
+		res.remove(cat, opt)
+	default:
+		res.add(m, cat, opt)
+	}
+
+	return res
+}
+
+// add chooses opt in category cat, replacing the option already chosen unless
+// cat is a multi-select category of m
+func (r Recipe) add(m *Manifest, cat, opt string) {
+	if c := m.Category(cat); c == nil || !c.Multi {
+		r[cat] = []string{opt}
+		return
+	}
+
+	if !r.Has(cat, opt) {
+		r[cat] = sorted(append(r[cat], opt))
+	}
+}
+
+// remove stops choosing opt in category cat, or every option in cat if opt is
+// empty
+func (r Recipe) remove(cat, opt string) {
+	var opts []string
+	if opt != "" {
+		for _, o := range r[cat] {
+			if o != opt {
+				opts = append(opts, o)
+			}
+		}
+	}
+
+	if len(opts) == 0 {
+		delete(r, cat)
+	} else {
+		r[cat] = opts
+	}
+}
+
+// Layer is an option chosen in a category of a gopher
+type Layer struct {
+	Category *Category
+	Option   *Option
+}
+
+// ID returns an identifier for l that is unique within a gopher, of the form
+// category-option
+func (l Layer) ID() string {
+	return l.Category.ID + idSep + l.Option.ID
+}
+
+// Layers returns the layers of r in the order they are drawn, bottom first:
+// in category order, and within a category in the order of its options.
+// Categories and options in r that are not in m are ignored; use Validate to
+// reject them.
+func (r Recipe) Layers(m *Manifest) []Layer {
+	var res []Layer
+
+	for _, c := range m.Categories {
+		if len(r[c.ID]) == 0 {
+			continue
+		}
+
+		for _, o := range c.Options {
+			if r.Has(c.ID, o.ID) {
+				res = append(res, Layer{Category: c, Option: o})
+			}
+		}
+	}
+
+	return res
+}
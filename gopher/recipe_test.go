@@ -0,0 +1,166 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package gopher_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/myitcv/gopherize.me/gopher"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	tests := []struct {
+		name    string
+		recipe  gopher.Recipe
+		encoded string
+	}{
+		{
+			name:    "single",
+			recipe:  gopher.Recipe{"body": {"blue_gopher"}},
+			encoded: "1.body-blue_gopher",
+		},
+		{
+			name:    "categories in ID order",
+			recipe:  gopher.Recipe{"shirts": {"docker_shirt"}, "body": {"blue_gopher"}, "eyes": {"eyes"}},
+			encoded: "1.body-blue_gopher.eyes-eyes.shirts-docker_shirt",
+		},
+		{
+			name:    "multi-select",
+			recipe:  gopher.Recipe{"body": {"blue_gopher"}, "extras": {"coffee", "watch"}},
+			encoded: "1.body-blue_gopher.extras-coffee~watch",
+		},
+		{
+			name:    "colour",
+			recipe:  gopher.Recipe{"body": {"blue_gopher"}, gopher.ColourKey: {"ff8800"}},
+			encoded: "1.body-blue_gopher.colour-ff8800",
+		},
+		{
+			name:    "mixed case IDs",
+			recipe:  gopher.Recipe{"extras": {"Large_black_yellow_bow"}},
+			encoded: "1.extras-Large_black_yellow_bow",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.recipe.Encode(); got != tc.encoded {
+				t.Fatalf("Encode() = %q; want %q", got, tc.encoded)
+			}
+
+			got, err := gopher.Decode(tc.encoded)
+			if err != nil {
+				t.Fatalf("Decode(%q) failed: %v", tc.encoded, err)
+			}
+			if !reflect.DeepEqual(got, tc.recipe) {
+				t.Fatalf("Decode(%q) = %#v; want %#v", tc.encoded, got, tc.recipe)
+			}
+		})
+	}
+}
+
+func TestEncodeCanonical(t *testing.T) {
+	// options are encoded in ID order whatever order they are held in, and
+	// categories with no option chosen are left out
+	r := gopher.Recipe{"extras": {"watch", "coffee"}, "hair": nil}
+
+	if got, want := r.Encode(), "1.extras-coffee~watch"; got != want {
+		t.Fatalf("Encode() = %q; want %q", got, want)
+	}
+}
+
+func TestDecodeSingleOptionLink(t *testing.T) {
+	// links shared before categories could have several options chosen
+	// still decode, with one option in each category
+	const link = "1.body-pink_gopher.eyes-eyelashes.hair-red_hair_pink_ears"
+
+	got, err := gopher.Decode(link)
+	if err != nil {
+		t.Fatalf("Decode(%q) failed: %v", link, err)
+	}
+
+	want := gopher.Recipe{
+		"body": {"pink_gopher"},
+		"eyes": {"eyelashes"},
+		"hair": {"red_hair_pink_ears"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Decode(%q) = %#v; want %#v", link, got, want)
+	}
+
+	if got.Encode() != link {
+		t.Fatalf("Encode() = %q; want %q", got.Encode(), link)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		encoded string
+		err     string
+	}{
+		{"empty", "", "unknown recipe version"},
+		{"bad version", "2.body-blue_gopher", "unknown recipe version"},
+		{"no option", "1.body", "invalid layer"},
+		{"empty option", "1.body-", "invalid layer"},
+		{"empty multi-select option", "1.extras-coffee~", "invalid layer"},
+		{"empty category", "1.-blue_gopher", "invalid layer"},
+		{"invalid category", "1.bo dy-blue_gopher", "invalid layer"},
+		{"invalid option", "1.body-blue/gopher", "invalid layer"},
+		{"empty layer", "1..body-blue_gopher", "invalid layer"},
+		{"duplicate category", "1.body-blue_gopher.body-pink_gopher", "appears more than once"},
+		{"duplicate option", "1.extras-coffee~coffee", "appears more than once"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := gopher.Decode(tc.encoded)
+			if err == nil {
+				t.Fatalf("Decode(%q) succeeded; want error", tc.encoded)
+			}
+			if !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("Decode(%q) error %q does not contain %q", tc.encoded, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	m := &gopher.Manifest{
+		Categories: []*gopher.Category{
+			{ID: "body", Options: []*gopher.Option{{ID: "blue_gopher"}, {ID: "pink_gopher"}}},
+			{ID: "extras", Multi: true, Options: []*gopher.Option{{ID: "coffee"}, {ID: "watch"}}},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		recipe gopher.Recipe
+		err    string
+	}{
+		{"valid", gopher.Recipe{"body": {"blue_gopher"}, "extras": {"coffee", "watch"}}, ""},
+		{"coloured", gopher.Recipe{"body": {"blue_gopher"}, gopher.ColourKey: {"ff8800"}}, ""},
+		{"empty", gopher.Recipe{}, "recipe is empty"},
+		{"colour alone", gopher.Recipe{gopher.ColourKey: {"ff8800"}}, "recipe is empty"},
+		{"bad colour", gopher.Recipe{"body": {"blue_gopher"}, gopher.ColourKey: {"FF8800"}}, "invalid colour"},
+		{"unknown category", gopher.Recipe{"hats": {"cap"}}, "unknown category"},
+		{"unknown option", gopher.Recipe{"body": {"green_gopher"}}, "unknown option"},
+		{"several in single-select", gopher.Recipe{"body": {"blue_gopher", "pink_gopher"}}, "only one option"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.recipe.Validate(m)
+			switch {
+			case tc.err == "" && err != nil:
+				t.Fatalf("Validate() failed: %v", err)
+			case tc.err != "" && err == nil:
+				t.Fatalf("Validate() succeeded; want error containing %q", tc.err)
+			case tc.err != "" && !strings.Contains(err.Error(), tc.err):
+				t.Fatalf("Validate() error %q does not contain %q", err, tc.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,298 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package gopher
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Ref refers to an option within a category, or, if Option is empty, to any
+// option in the category
+type Ref struct {
+	Category string
+	Option   string
+}
+
+// ParseRef parses a reference of the form category-option, as in an encoded
+// recipe, or category
+func ParseRef(s string) (Ref, error) {
+	var res Ref
+
+	if i := strings.Index(s, idSep); i != -1 {
+		res.Category, res.Option = s[:i], s[i+len(idSep):]
+		if !validID(res.Option) {
+			return Ref{}, fmt.Errorf("invalid reference %q", s)
+		}
+	} else {
+		res.Category = s
+	}
+
+	if !validID(res.Category) {
+		return Ref{}, fmt.Errorf("invalid reference %q", s)
+	}
+
+	return res, nil
+}
+
+func (f Ref) String() string {
+	if f.Option == "" {
+		return f.Category
+	}
+
+	return f.Category + idSep + f.Option
+}
+
+// In reports whether r chooses the option f refers to, or any option in f's
+// category if f has no option
+func (f Ref) In(r Recipe) bool {
+	if f.Option == "" {
+		return len(r[f.Category]) > 0
+	}
+
+	return r.Has(f.Category, f.Option)
+}
+
+// Matches reports whether choosing opt in category cat would choose f
+func (f Ref) Matches(cat, opt string) bool {
+	return f.Category == cat && (f.Option == "" || f.Option == opt)
+}
+
+// Rule constrains the options that can be combined with If
+type Rule struct {
+	// If is the option, or category, to which the rule applies
+	If Ref
+
+	// Excludes are the options that cannot be chosen together with If, e.g.
+	// shirts with a full-body costume. An option never excludes itself, so
+	// the same list can be given for each of a group of options that exclude
+	// one another.
+	Excludes []Ref
+
+	// Requires are the options of which at least one must be chosen together
+	// with If, e.g. the body matching the ears drawn as part of some hair.
+	// Where every option required can be recoloured (see Option.Colour), a
+	// recipe that chooses a colour need not choose any of them: the colours
+	// they would match are all recoloured alike.
+	Requires []Ref
+
+	// Prefers are the options that go best with If. They do not constrain a
+	// recipe; instead, where a category has an option chosen but none of
+	// those preferred, Random chooses a preferred one, and the picker
+	// suggests them.
+	Prefers []Ref
+}
+
+func (rl *Rule) String() string {
+	var parts []string
+
+	add := func(verb string, fs []Ref) {
+		if len(fs) == 0 {
+			return
+		}
+		var ss []string
+		for _, f := range fs {
+			ss = append(ss, f.String())
+		}
+		parts = append(parts, verb+" "+strings.Join(ss, ", "))
+	}
+
+	add("excludes", rl.Excludes)
+	add("requires", rl.Requires)
+	add("prefers", rl.Prefers)
+
+	return rl.If.String() + " " + strings.Join(parts, "; ")
+}
+
+// requires returns the options of which at least one must be chosen together
+// with rl.If in r, which are none if r's colour makes them all alike
+func (rl *Rule) requires(m *Manifest, r Recipe) []Ref {
+	if r.Colour() == "" {
+		return rl.Requires
+	}
+
+	for _, f := range rl.Requires {
+		if f.Option == "" {
+			return rl.Requires
+		}
+		c := m.Category(f.Category)
+		if c == nil {
+			return rl.Requires
+		}
+		if o := c.Option(f.Option); o == nil || o.Colour == "" {
+			return rl.Requires
+		}
+	}
+
+	return nil
+}
+
+// check returns an error describing how r breaks rl, or nil
+func (rl *Rule) check(m *Manifest, r Recipe) error {
+	if !rl.If.In(r) {
+		return nil
+	}
+
+	for _, f := range rl.Excludes {
+		if f == rl.If || !f.In(r) {
+			continue
+		}
+		if f.Option == "" {
+			f.Option = r[f.Category][0]
+		}
+		return fmt.Errorf("%v cannot be combined with %v", rl.If, f)
+	}
+
+	if fs := rl.requires(m, r); len(fs) > 0 && !anyIn(fs, r) {
+		var ss []string
+		for _, f := range fs {
+			ss = append(ss, f.String())
+		}
+		return fmt.Errorf("%v requires %v", rl.If, strings.Join(ss, " or "))
+	}
+
+	return nil
+}
+
+func anyIn(fs []Ref, r Recipe) bool {
+	for _, f := range fs {
+		if f.In(r) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CheckRules returns an error describing the first rule of m that r breaks,
+// or nil if r breaks none
+func (r Recipe) CheckRules(m *Manifest) error {
+	for _, rl := range m.Rules {
+		if err := rl.check(m, r); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Resolve returns a copy of r that breaks none of the rules of m. Where a
+// rule is broken the option keep, if it is chosen, is kept and others are
+// changed: excluded options are removed, and an unmet requirement is met by
+// choosing the first option it names. If that is not possible the option to
+// which the rule applies is removed instead. A keep with no option keeps
+// every option in its category.
+func (r Recipe) Resolve(m *Manifest, keep Ref) Recipe {
+	res := r.Clone()
+
+	// kept reports whether removing f would remove keep
+	kept := func(f Ref) bool {
+		return f.Category == keep.Category && (f.Option == "" || keep.Option == "" || f.Option == keep.Option)
+	}
+
+	// each pass fixes rules in turn; fixing one can break another, so repeat
+	// a bounded number of times before falling back to removing options
+	for i := 0; i <= len(m.Rules); i++ {
+		changed := false
+
+		for _, rl := range m.Rules {
+			if !rl.If.In(res) {
+				continue
+			}
+
+			for _, f := range rl.Excludes {
+				if f == rl.If || !f.In(res) {
+					continue
+				}
+				if kept(f) {
+					res.remove(rl.If.Category, rl.If.Option)
+				} else {
+					res.remove(f.Category, f.Option)
+				}
+				changed = true
+			}
+
+			fs := rl.requires(m, res)
+			if !rl.If.In(res) || len(fs) == 0 || anyIn(fs, res) {
+				continue
+			}
+
+			f := fs[0]
+			opt := f.Option
+			if opt == "" {
+				if c := m.Category(f.Category); c != nil && len(c.Options) > 0 {
+					opt = c.Options[0].ID
+				}
+			}
+
+			if f.Category == keep.Category || opt == "" {
+				res.remove(rl.If.Category, rl.If.Option)
+			} else {
+				res.add(m, f.Category, opt)
+			}
+			changed = true
+		}
+
+		if !changed {
+			return res
+		}
+	}
+
+	for broken := true; broken; {
+		broken = false
+		for _, rl := range m.Rules {
+			if rl.check(m, res) != nil {
+				res.remove(rl.If.Category, rl.If.Option)
+				broken = true
+			}
+		}
+	}
+
+	return res
+}
+
+// Excluded reports whether choosing opt in category cat would break an
+// exclusion of m's rules given the other options chosen in r
+func (m *Manifest) Excluded(r Recipe, cat, opt string) bool {
+	// rest is what else would be chosen were opt chosen
+	rest := r.Clone()
+	if c := m.Category(cat); c != nil && c.Multi {
+		rest.remove(cat, opt)
+	} else {
+		delete(rest, cat)
+	}
+
+	for _, rl := range m.Rules {
+		for _, f := range rl.Excludes {
+			if f == rl.If {
+				continue
+			}
+			switch {
+			case rl.If.Matches(cat, opt) && f.In(rest):
+				return true
+			case f.Matches(cat, opt) && rl.If.In(rest):
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Preferred reports whether a rule of m that applies to r prefers opt in
+// category cat
+func (m *Manifest) Preferred(r Recipe, cat, opt string) bool {
+	for _, rl := range m.Rules {
+		if rl.If.Category == cat || !rl.If.In(r) {
+			continue
+		}
+		for _, f := range rl.Prefers {
+			if f.Matches(cat, opt) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
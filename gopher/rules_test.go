@@ -0,0 +1,299 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package gopher_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/myitcv/gopherize.me/artwork"
+	"github.com/myitcv/gopherize.me/gopher"
+)
+
+// rulesManifest is a small manifest with one of each kind of rule
+func rulesManifest() *gopher.Manifest {
+	ref := func(s string) gopher.Ref {
+		f, err := gopher.ParseRef(s)
+		if err != nil {
+			panic(err)
+		}
+		return f
+	}
+	refs := func(ss ...string) []gopher.Ref {
+		var res []gopher.Ref
+		for _, s := range ss {
+			res = append(res, ref(s))
+		}
+		return res
+	}
+
+	held := refs("extras-coffee", "extras-camera")
+
+	return &gopher.Manifest{
+		Categories: []*gopher.Category{
+			{ID: "body", Options: []*gopher.Option{
+				{ID: "blue_gopher", Colour: "abc3d6"},
+				{ID: "pink_gopher", Colour: "ffcaca"},
+			}},
+			{ID: "shirts", Options: []*gopher.Option{{ID: "tee"}}},
+			{ID: "hair", Options: []*gopher.Option{
+				{ID: "blue_ears", Colour: "c0e4e3"},
+				{ID: "red_hair"},
+				{ID: "tall_hair"},
+			}},
+			{ID: "facial_hair", Options: []*gopher.Option{{ID: "black_beard"}, {ID: "red_beard"}}},
+			{ID: "costumes", Options: []*gopher.Option{{ID: "bat"}}},
+			{ID: "hats", Options: []*gopher.Option{{ID: "cap"}}},
+			{ID: "extras", Multi: true, Options: []*gopher.Option{
+				{ID: "camera"},
+				{ID: "coffee"},
+				{ID: "watch"},
+			}},
+		},
+		Rules: []*gopher.Rule{
+			{If: ref("costumes-bat"), Excludes: refs("shirts")},
+			{If: ref("hats-cap"), Excludes: refs("hair-tall_hair")},
+			{If: ref("hair-blue_ears"), Requires: refs("body-blue_gopher")},
+			{If: ref("hair-red_hair"), Prefers: refs("facial_hair-red_beard")},
+			{If: held[0], Excludes: held},
+			{If: held[1], Excludes: held},
+		},
+	}
+}
+
+func TestResolve(t *testing.T) {
+	m := rulesManifest()
+
+	tests := []struct {
+		name   string
+		recipe string
+		keep   string
+		want   string
+	}{
+		{
+			name:   "exclude keeps the option excluding",
+			recipe: "1.costumes-bat.shirts-tee",
+			keep:   "costumes-bat",
+			want:   "1.costumes-bat",
+		},
+		{
+			name:   "exclude keeps the option excluded",
+			recipe: "1.costumes-bat.shirts-tee",
+			keep:   "shirts-tee",
+			want:   "1.shirts-tee",
+		},
+		{
+			name:   "exclude without keep removes the option excluded",
+			recipe: "1.hair-tall_hair.hats-cap",
+			want:   "1.hats-cap",
+		},
+		{
+			name:   "exclude keeps a whole category",
+			recipe: "1.hair-tall_hair.hats-cap",
+			keep:   "hair",
+			want:   "1.hair-tall_hair",
+		},
+		{
+			name:   "requirement met by adding an option",
+			recipe: "1.body-pink_gopher.hair-blue_ears",
+			keep:   "hair-blue_ears",
+			want:   "1.body-blue_gopher.hair-blue_ears",
+		},
+		{
+			name:   "requirement met by removing the option requiring",
+			recipe: "1.body-pink_gopher.hair-blue_ears",
+			keep:   "body-pink_gopher",
+			want:   "1.body-pink_gopher",
+		},
+		{
+			name:   "requirement met by adding to an empty category",
+			recipe: "1.hair-blue_ears",
+			want:   "1.body-blue_gopher.hair-blue_ears",
+		},
+		{
+			name:   "requirement waived by a colour",
+			recipe: "1.body-pink_gopher.colour-ff0000.hair-blue_ears",
+			keep:   "hair-blue_ears",
+			want:   "1.body-pink_gopher.colour-ff0000.hair-blue_ears",
+		},
+		{
+			name:   "group exclusion keeps the option chosen",
+			recipe: "1.extras-camera~coffee",
+			keep:   "extras-coffee",
+			want:   "1.extras-coffee",
+		},
+		{
+			name:   "group exclusion leaves other options",
+			recipe: "1.extras-camera~watch",
+			keep:   "extras-camera",
+			want:   "1.extras-camera~watch",
+		},
+		{
+			name:   "preferences do not constrain",
+			recipe: "1.facial_hair-black_beard.hair-red_hair",
+			want:   "1.facial_hair-black_beard.hair-red_hair",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := gopher.Decode(tc.recipe)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var keep gopher.Ref
+			if tc.keep != "" {
+				if keep, err = gopher.ParseRef(tc.keep); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got := r.Resolve(m, keep)
+
+			if got.Encode() != tc.want {
+				t.Fatalf("Resolve(%v) = %v; want %v", keep, got, tc.want)
+			}
+			if err := got.CheckRules(m); err != nil {
+				t.Fatalf("Resolve(%v) = %v, which breaks a rule: %v", keep, got, err)
+			}
+			if r.Encode() != tc.recipe {
+				t.Fatalf("Resolve changed its receiver to %v", r)
+			}
+		})
+	}
+}
+
+func TestCheckRules(t *testing.T) {
+	m := rulesManifest()
+
+	tests := []struct {
+		recipe string
+		err    string
+	}{
+		{"1.costumes-bat", ""},
+		{"1.costumes-bat.shirts-tee", "costumes-bat cannot be combined with shirts-tee"},
+		{"1.hair-blue_ears", "hair-blue_ears requires body-blue_gopher"},
+		{"1.body-pink_gopher.hair-blue_ears", "hair-blue_ears requires body-blue_gopher"},
+		{"1.body-pink_gopher.colour-ff0000.hair-blue_ears", ""},
+		{"1.body-blue_gopher.hair-blue_ears", ""},
+		{"1.extras-coffee", ""},
+		{"1.extras-coffee~watch", ""},
+		{"1.extras-camera~coffee", "cannot be combined with"},
+		{"1.facial_hair-black_beard.hair-red_hair", ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.recipe, func(t *testing.T) {
+			r, err := gopher.Decode(tc.recipe)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = r.CheckRules(m)
+			switch {
+			case tc.err == "" && err != nil:
+				t.Fatalf("CheckRules() failed: %v", err)
+			case tc.err != "" && err == nil:
+				t.Fatalf("CheckRules() succeeded; want error containing %q", tc.err)
+			case tc.err != "" && !strings.Contains(err.Error(), tc.err):
+				t.Fatalf("CheckRules() error %q does not contain %q", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestValidateIgnoresRules(t *testing.T) {
+	// rules are added as artwork is, so must not invalidate existing recipes
+	m := rulesManifest()
+
+	for _, enc := range []string{"1.costumes-bat.shirts-tee", "1.hair-blue_ears", "1.extras-camera~coffee"} {
+		r, err := gopher.Decode(enc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := r.Validate(m); err != nil {
+			t.Errorf("Validate(%v) failed: %v", enc, err)
+		}
+		if err := r.Resolve(m, gopher.Ref{}).CheckRules(m); err != nil {
+			t.Errorf("Resolve(%v) breaks a rule: %v", enc, err)
+		}
+	}
+}
+
+func TestExcluded(t *testing.T) {
+	m := rulesManifest()
+
+	tests := []struct {
+		recipe string
+		cat    string
+		opt    string
+		want   bool
+	}{
+		{"1.costumes-bat", "shirts", "tee", true},
+		{"1.shirts-tee", "costumes", "bat", true},
+		{"1.costumes-bat", "costumes", "bat", false},
+		{"1.hats-cap", "hair", "tall_hair", true},
+		{"1.hats-cap", "hair", "red_hair", false},
+
+		// choosing an option replaces the one already chosen in a
+		// single-select category, so cannot conflict with it
+		{"1.hair-tall_hair", "hair", "red_hair", false},
+
+		// an option never excludes itself, nor options outside its group
+		{"1.extras-coffee", "extras", "coffee", false},
+		{"1.extras-coffee", "extras", "camera", true},
+		{"1.extras-coffee", "extras", "watch", false},
+		{"1.extras-watch", "extras", "camera", false},
+
+		// requirements are not exclusions
+		{"1.body-pink_gopher", "hair", "blue_ears", false},
+	}
+
+	for _, tc := range tests {
+		r, err := gopher.Decode(tc.recipe)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := m.Excluded(r, tc.cat, tc.opt); got != tc.want {
+			t.Errorf("Excluded(%v, %v, %v) = %v; want %v", tc.recipe, tc.cat, tc.opt, got, tc.want)
+		}
+	}
+}
+
+func TestPreferred(t *testing.T) {
+	m := rulesManifest()
+
+	r := gopher.Recipe{"hair": {"red_hair"}}
+
+	if !m.Preferred(r, "facial_hair", "red_beard") {
+		t.Errorf("red_beard is not preferred with red_hair")
+	}
+	if m.Preferred(r, "facial_hair", "black_beard") {
+		t.Errorf("black_beard is preferred with red_hair")
+	}
+	if m.Preferred(gopher.Recipe{}, "facial_hair", "red_beard") {
+		t.Errorf("red_beard is preferred without red_hair")
+	}
+}
+
+func TestRandomValid(t *testing.T) {
+	for _, m := range []*gopher.Manifest{rulesManifest(), artwork.Default} {
+		for seed := int64(0); seed < 2000; seed++ {
+			r := gopher.Random(m, seed)
+
+			if err := r.Validate(m); err != nil {
+				t.Fatalf("Random(%v) = %v, which is invalid: %v", seed, r, err)
+			}
+			if err := r.CheckRules(m); err != nil {
+				t.Fatalf("Random(%v) = %v, which breaks a rule: %v", seed, r, err)
+			}
+
+			if again := gopher.Random(m, seed); !again.Equals(r) {
+				t.Fatalf("Random(%v) gave %v then %v", seed, r, again)
+			}
+		}
+	}
+}
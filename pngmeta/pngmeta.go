@@ -0,0 +1,273 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+// Package pngmeta reads and writes the textual metadata of PNG images, the
+// tEXt and iTXt chunks, without decoding the images themselves.
+//
+// It is pure Go with no dependencies beyond the standard library, so that it
+// can be used by the client as well as the server.
+package pngmeta
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"sort"
+	"unicode/utf8"
+)
+
+const (
+	// RecipeKey is the keyword of the chunk holding the encoded recipe of a
+	// rendered gopher
+	RecipeKey = "gopherize.recipe"
+
+	// ArtworkVersionKey is the keyword of the chunk holding the version of
+	// the artwork from which a gopher was rendered
+	ArtworkVersionKey = "gopherize.artwork-version"
+
+	pngHeader = "\x89PNG\r\n\x1a\n"
+
+	chunkIHDR = "IHDR"
+	chunkIEND = "IEND"
+	chunkTEXt = "tEXt"
+	chunkITXt = "iTXt"
+
+	// maxChunkLen is the longest chunk allowed by the PNG specification
+	maxChunkLen = 1<<31 - 1
+
+	// maxKeywordLen is the longest keyword allowed by the PNG specification
+	maxKeywordLen = 79
+)
+
+type chunk struct {
+	typ  string
+	data []byte
+}
+
+// Read returns the text of every tEXt and iTXt chunk of the PNG image read
+// from r, keyed by keyword. If a keyword appears more than once the last
+// value wins.
+func Read(r io.Reader) (map[string]string, error) {
+	cs, err := readChunks(r)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]string)
+
+	for _, c := range cs {
+		var k, v string
+		var err error
+
+		switch c.typ {
+		case chunkTEXt:
+			k, v, err = parseText(c.data)
+		case chunkITXt:
+			k, v, err = parseIText(c.data)
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		res[k] = v
+	}
+
+	return res, nil
+}
+
+// Insert returns a copy of the PNG image p with a chunk for each of text,
+// written immediately after the image header. Existing text chunks with the
+// same keywords are removed. Values that are not Latin-1 are written as
+// UTF-8 iTXt chunks, the rest as tEXt chunks.
+func Insert(p []byte, text map[string]string) ([]byte, error) {
+	cs, err := readChunks(bytes.NewReader(p))
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(text))
+	for k := range text {
+		if k == "" || len(k) > maxKeywordLen {
+			return nil, fmt.Errorf("invalid keyword %q; must be 1 to %v bytes", k, maxKeywordLen)
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var added []chunk
+	for _, k := range keys {
+		added = append(added, textChunk(k, text[k]))
+	}
+
+	buf := new(bytes.Buffer)
+	buf.WriteString(pngHeader)
+
+	for _, c := range cs {
+		if c.typ == chunkTEXt || c.typ == chunkITXt {
+			var k string
+			if i := bytes.IndexByte(c.data, 0); i != -1 {
+				k = string(c.data[:i])
+			}
+			if _, ok := text[k]; ok {
+				continue
+			}
+		}
+
+		writeChunk(buf, c)
+
+		if c.typ == chunkIHDR {
+			for _, a := range added {
+				writeChunk(buf, a)
+			}
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+func readChunks(r io.Reader) ([]chunk, error) {
+	hdr := make([]byte, len(pngHeader))
+	if _, err := io.ReadFull(r, hdr); err != nil || string(hdr) != pngHeader {
+		return nil, fmt.Errorf("not a PNG image")
+	}
+
+	var res []chunk
+
+	for {
+		var lt [8]byte
+		if _, err := io.ReadFull(r, lt[:]); err != nil {
+			return nil, fmt.Errorf("could not read chunk header: %v", err)
+		}
+
+		n := binary.BigEndian.Uint32(lt[:4])
+		c := chunk{typ: string(lt[4:])}
+
+		if n > maxChunkLen {
+			return nil, fmt.Errorf("%v chunk is too long", c.typ)
+		}
+
+		// data and CRC
+		b := make([]byte, int64(n)+4)
+		if _, err := io.ReadFull(r, b); err != nil {
+			return nil, fmt.Errorf("could not read %v chunk: %v", c.typ, err)
+		}
+		c.data = b[:n]
+
+		crc := crc32.NewIEEE()
+		crc.Write(lt[4:])
+		crc.Write(c.data)
+		if crc.Sum32() != binary.BigEndian.Uint32(b[n:]) {
+			return nil, fmt.Errorf("bad CRC in %v chunk", c.typ)
+		}
+
+		res = append(res, c)
+
+		if c.typ == chunkIEND {
+			return res, nil
+		}
+	}
+}
+
+func writeChunk(w *bytes.Buffer, c chunk) {
+	var n [4]byte
+	binary.BigEndian.PutUint32(n[:], uint32(len(c.data)))
+	w.Write(n[:])
+
+	crc := crc32.NewIEEE()
+	io.WriteString(crc, c.typ)
+	crc.Write(c.data)
+
+	w.WriteString(c.typ)
+	w.Write(c.data)
+
+	binary.BigEndian.PutUint32(n[:], crc.Sum32())
+	w.Write(n[:])
+}
+
+// textChunk returns a tEXt chunk for k and v if v is Latin-1, else an
+// uncompressed iTXt chunk
+func textChunk(k, v string) chunk {
+	if latin1, ok := toLatin1(v); ok {
+		return chunk{
+			typ:  chunkTEXt,
+			data: append(append([]byte(k), 0), latin1...),
+		}
+	}
+
+	// keyword, null, compression flag and method, empty language tag and
+	// translated keyword, each null terminated
+	d := append([]byte(k), 0, 0, 0, 0, 0)
+	d = append(d, v...)
+
+	return chunk{typ: chunkITXt, data: d}
+}
+
+func toLatin1(s string) ([]byte, bool) {
+	var res []byte
+	for _, r := range s {
+		if r > 0xff || r == utf8.RuneError {
+			return nil, false
+		}
+		res = append(res, byte(r))
+	}
+	return res, true
+}
+
+func parseText(d []byte) (string, string, error) {
+	i := bytes.IndexByte(d, 0)
+	if i == -1 {
+		return "", "", fmt.Errorf("malformed tEXt chunk")
+	}
+
+	// tEXt is Latin-1
+	rs := make([]rune, 0, len(d)-i-1)
+	for _, b := range d[i+1:] {
+		rs = append(rs, rune(b))
+	}
+
+	return string(d[:i]), string(rs), nil
+}
+
+func parseIText(d []byte) (string, string, error) {
+	bad := fmt.Errorf("malformed iTXt chunk")
+
+	i := bytes.IndexByte(d, 0)
+	if i == -1 || len(d) < i+3 {
+		return "", "", bad
+	}
+	k := string(d[:i])
+	compressed := d[i+1] == 1
+	rest := d[i+3:]
+
+	// skip the language tag and translated keyword
+	for j := 0; j < 2; j++ {
+		n := bytes.IndexByte(rest, 0)
+		if n == -1 {
+			return "", "", bad
+		}
+		rest = rest[n+1:]
+	}
+
+	if !compressed {
+		return k, string(rest), nil
+	}
+
+	zr, err := zlib.NewReader(bytes.NewReader(rest))
+	if err != nil {
+		return "", "", fmt.Errorf("could not decompress iTXt chunk %v: %v", k, err)
+	}
+	defer zr.Close()
+
+	v, err := ioutil.ReadAll(zr)
+	if err != nil {
+		return "", "", fmt.Errorf("could not decompress iTXt chunk %v: %v", k, err)
+	}
+
+	return k, string(v), nil
+}
@@ -0,0 +1,233 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package pngmeta
+
+import (
+	"bytes"
+	"compress/zlib"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+// testPNG returns a small encoded PNG image
+func testPNG(t *testing.T) []byte {
+	t.Helper()
+
+	img := image.NewNRGBA(image.Rect(0, 0, 4, 3))
+	img.Set(1, 1, color.NRGBA{R: 0x12, G: 0x34, B: 0x56, A: 0xff})
+
+	buf := new(bytes.Buffer)
+	if err := png.Encode(buf, img); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+// chunkTypes returns the type of each chunk of p whose keyword is k
+func chunkTypes(t *testing.T, p []byte, k string) []string {
+	t.Helper()
+
+	cs, err := readChunks(bytes.NewReader(p))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var res []string
+	for _, c := range cs {
+		if (c.typ == chunkTEXt || c.typ == chunkITXt) && bytes.HasPrefix(c.data, append([]byte(k), 0)) {
+			res = append(res, c.typ)
+		}
+	}
+
+	return res
+}
+
+func TestInsertRead(t *testing.T) {
+	orig := testPNG(t)
+
+	text := map[string]string{
+		RecipeKey:         "1.body-blue_gopher.eyes-eyes",
+		ArtworkVersionKey: "0123456789abcdef",
+		"Latin-1":         "café crème",
+		"UTF-8":           "gopher 世界",
+	}
+
+	p, err := Insert(orig, text)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Read(bytes.NewReader(p))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for k, v := range text {
+		if got[k] != v {
+			t.Errorf("Read()[%q] = %q; want %q", k, got[k], v)
+		}
+	}
+	if len(got) != len(text) {
+		t.Errorf("Read() = %q; want %v entries", got, len(text))
+	}
+
+	if ts := chunkTypes(t, p, "Latin-1"); len(ts) != 1 || ts[0] != chunkTEXt {
+		t.Errorf("Latin-1 value written as %v; want one tEXt chunk", ts)
+	}
+	if ts := chunkTypes(t, p, "UTF-8"); len(ts) != 1 || ts[0] != chunkITXt {
+		t.Errorf("UTF-8 value written as %v; want one iTXt chunk", ts)
+	}
+
+	// the image itself is unchanged
+	a, err := png.Decode(bytes.NewReader(orig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := png.Decode(bytes.NewReader(p))
+	if err != nil {
+		t.Fatalf("could not decode image after Insert: %v", err)
+	}
+	if string(a.(*image.NRGBA).Pix) != string(b.(*image.NRGBA).Pix) {
+		t.Errorf("Insert changed the pixels of the image")
+	}
+}
+
+func TestInsertReplaces(t *testing.T) {
+	p, err := Insert(testPNG(t), map[string]string{
+		RecipeKey: "1.body-blue_gopher",
+		"Other":   "kept",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// replacing a tEXt value with one that needs iTXt removes the tEXt chunk
+	p, err = Insert(p, map[string]string{RecipeKey: "1.body-pink_gopher 世"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Read(bytes.NewReader(p))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "1.body-pink_gopher 世"; got[RecipeKey] != want {
+		t.Errorf("Read()[%q] = %q; want %q", RecipeKey, got[RecipeKey], want)
+	}
+	if got["Other"] != "kept" {
+		t.Errorf("Read()[%q] = %q; want %q", "Other", got["Other"], "kept")
+	}
+	if ts := chunkTypes(t, p, RecipeKey); len(ts) != 1 {
+		t.Errorf("%v written as %v chunks; want one", RecipeKey, ts)
+	}
+}
+
+func TestInsertInvalidKeyword(t *testing.T) {
+	for _, k := range []string{"", strings.Repeat("k", maxKeywordLen+1)} {
+		if _, err := Insert(testPNG(t), map[string]string{k: "v"}); err == nil {
+			t.Errorf("Insert with keyword %q succeeded", k)
+		}
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	p, err := Insert(testPNG(t), map[string]string{RecipeKey: "1.body-blue_gopher"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the last byte of the IHDR chunk's data, just before its CRC
+	badCRC := append([]byte(nil), p...)
+	badCRC[len(pngHeader)+8+13-1] ^= 0xff
+
+	tests := []struct {
+		name string
+		p    []byte
+		err  string
+	}{
+		{"empty", nil, "not a PNG image"},
+		{"not a PNG", []byte("GIF89a and then some"), "not a PNG image"},
+		{"bad CRC", badCRC, "bad CRC in IHDR chunk"},
+		{"truncated header", p[:len(pngHeader)+4], "could not read chunk header"},
+		{"truncated chunk", p[:len(pngHeader)+8+6], "could not read IHDR chunk"},
+		{"no IEND", p[:len(p)-12], "could not read chunk header"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Read(bytes.NewReader(tc.p))
+			if err == nil {
+				t.Fatalf("Read() succeeded; want error containing %q", tc.err)
+			}
+			if !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("Read() error %q does not contain %q", err, tc.err)
+			}
+
+			if _, err := Insert(tc.p, map[string]string{"k": "v"}); err == nil {
+				t.Fatalf("Insert() succeeded on a broken image")
+			}
+		})
+	}
+}
+
+func TestReadCompressedIText(t *testing.T) {
+	const k, v = "Description", "a gopher 世界, compressed"
+
+	z := new(bytes.Buffer)
+	zw := zlib.NewWriter(z)
+	zw.Write([]byte(v))
+	zw.Close()
+
+	// keyword, compression flag and method, language tag and translated
+	// keyword
+	d := append([]byte(k), 0, 1, 0)
+	d = append(d, "en"...)
+	d = append(d, 0)
+	d = append(d, "Beschreibung"...)
+	d = append(d, 0)
+	d = append(d, z.Bytes()...)
+
+	cs, err := readChunks(bytes.NewReader(testPNG(t)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf := new(bytes.Buffer)
+	buf.WriteString(pngHeader)
+	for _, c := range cs {
+		writeChunk(buf, c)
+		if c.typ == chunkIHDR {
+			writeChunk(buf, chunk{typ: chunkITXt, data: d})
+		}
+	}
+
+	got, err := Read(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got[k] != v {
+		t.Fatalf("Read()[%q] = %q; want %q", k, got[k], v)
+	}
+
+	// corrupt the compressed text
+	d[len(d)-len(z.Bytes())] ^= 0xff
+
+	buf.Reset()
+	buf.WriteString(pngHeader)
+	for _, c := range cs {
+		writeChunk(buf, c)
+		if c.typ == chunkIHDR {
+			writeChunk(buf, chunk{typ: chunkITXt, data: d})
+		}
+	}
+
+	if _, err := Read(bytes.NewReader(buf.Bytes())); err == nil {
+		t.Fatalf("Read() of a corrupt compressed iTXt chunk succeeded")
+	}
+}
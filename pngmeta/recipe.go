@@ -0,0 +1,33 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package pngmeta
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/myitcv/gopherize.me/gopher"
+)
+
+// ReadRecipe reads the recipe and artwork version embedded in a rendered
+// gopher. It checks only the form of the recipe; use gopher.Recipe.Validate
+// to check it against a manifest.
+func ReadRecipe(r io.Reader) (gopher.Recipe, string, error) {
+	text, err := Read(r)
+	if err != nil {
+		return nil, "", err
+	}
+
+	enc, ok := text[RecipeKey]
+	if !ok {
+		return nil, "", fmt.Errorf("no recipe found in PNG")
+	}
+
+	rec, err := gopher.Decode(enc)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return rec, text[ArtworkVersionKey], nil
+}
@@ -0,0 +1,149 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package server
+
+import (
+	"fmt"
+	"image"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/myitcv/gopherize.me/avatar"
+	"github.com/myitcv/gopherize.me/compositor"
+	"github.com/myitcv/gopherize.me/gopher"
+)
+
+const (
+	// AvatarPath is the URL path beneath which avatars are served, e.g.
+	// /avatar/205e460b479e2e5b48aec07710c08d50
+	AvatarPath = "/avatar/"
+
+	// DefaultAvatarSize is the size of an avatar when none is requested, as
+	// for Gravatar
+	DefaultAvatarSize = 80
+
+	// registered avatars can change, so like renders avatars are cached for
+	// a day and then revalidated against their ETag
+	avatarCacheControl = "public, max-age=86400"
+)
+
+// SetAvatars sets the pool from which avatars are derived and the registry of
+// chosen avatars. Both may be nil; see package avatar.
+func (s *Server) SetAvatars(p avatar.Pool, reg avatar.Registry) {
+	s.pool = p
+	s.registry = reg
+}
+
+// SetAvatarRedirects sets the hosts to which the d query parameter of an
+// avatar request may redirect. By default it may redirect nowhere, so that
+// the server cannot be used to send people to an arbitrary site.
+func (s *Server) SetAvatarRedirects(hosts ...string) {
+	s.redirectHosts = make(map[string]bool)
+	for _, h := range hosts {
+		s.redirectHosts[strings.ToLower(h)] = true
+	}
+}
+
+// avatar serves AvatarPath, following Gravatar's API. The last element of the
+// path is the MD5 or SHA-256 key of an identity, optionally followed by .png.
+// The s (or size) query parameter sets the size of the square avatar. If the
+// key is registered its gopher is served, otherwise the d (or default) query
+// parameter decides what is served:
+//
+//	(empty), gopher  a gopher derived from the key
+//	identicon, monsterid, wavatar, retro, robohash
+//	                 the same, for compatibility with Gravatar
+//	mp, mm           the default gopher
+//	blank            a transparent image
+//	404              a 404 response
+//	http(s)://...    a redirect to that URL, if its host is one of those set
+//	                 by SetAvatarRedirects
+//
+// f=y (or forcedefault=y) ignores the registry.
+func (s *Server) avatar(w http.ResponseWriter, r *http.Request) {
+	if !allowGet(w, r) {
+		return
+	}
+
+	key := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, AvatarPath), pngExt)
+	key = strings.ToLower(key)
+	if !avatar.ValidKey(key) {
+		http.Error(w, fmt.Sprintf("invalid avatar key %q; expected a hex MD5 or SHA-256 hash", key), http.StatusNotFound)
+		return
+	}
+
+	q := r.URL.Query()
+
+	size := DefaultAvatarSize
+	if v := param(q.Get("s"), q.Get("size")); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > compositor.MaxSize {
+			http.Error(w, fmt.Sprintf("invalid size %q; expected an integer between 1 and %v", v, compositor.MaxSize), http.StatusBadRequest)
+			return
+		}
+		size = n
+	}
+
+	opts := &renderOpts{
+		Options: compositor.Options{
+			Size:   size,
+			Square: true,
+		},
+		ext: pngExt,
+	}
+
+	if rec, ok := s.registry.Lookup(key); ok && !isYes(param(q.Get("f"), q.Get("forcedefault"))) {
+		opts.recipe = rec
+		s.serveRender(w, r, opts, avatarCacheControl)
+		return
+	}
+
+	switch d := param(q.Get("d"), q.Get("default")); d {
+	case "", "gopher", "identicon", "monsterid", "wavatar", "retro", "robohash":
+		opts.recipe = avatar.Recipe(s.manifest, s.pool, key)
+		if len(opts.recipe) == 0 {
+			http.Error(w, "no avatar artwork available", http.StatusNotFound)
+			return
+		}
+	case "mp", "mm", "mysteryman":
+		opts.recipe = gopher.Default
+	case "blank":
+		w.Header().Set("Cache-Control", avatarCacheControl)
+		servePNG(w, r, image.NewNRGBA(image.Rect(0, 0, size, size)))
+		return
+	case "404":
+		http.NotFound(w, r)
+		return
+	default:
+		if u, err := url.Parse(d); err == nil && (u.Scheme == "http" || u.Scheme == "https") {
+			if !s.redirectHosts[strings.ToLower(u.Hostname())] {
+				http.Error(w, fmt.Sprintf("default %q is not on an allowed host", d), http.StatusBadRequest)
+				return
+			}
+			http.Redirect(w, r, d, http.StatusFound)
+			return
+		}
+		http.Error(w, fmt.Sprintf("invalid default %q", d), http.StatusBadRequest)
+		return
+	}
+
+	s.serveRender(w, r, opts, avatarCacheControl)
+}
+
+// param returns the first of vs that is not empty
+func param(vs ...string) string {
+	for _, v := range vs {
+		if v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
+
+func isYes(v string) bool {
+	return v == "y" || v == "yes" || v == "true" || v == "1"
+}
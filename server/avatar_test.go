@@ -0,0 +1,63 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package server_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/myitcv/gopherize.me/avatar"
+	"github.com/myitcv/gopherize.me/server"
+)
+
+func TestAvatarDefault(t *testing.T) {
+	s := testServer(t)
+	s.SetAvatarRedirects("example.com")
+
+	key := avatar.MD5Key("gopher@example.com")
+
+	tests := []struct {
+		d    string
+		want int
+	}{
+		{"", http.StatusOK},
+		{"identicon", http.StatusOK},
+		{"mp", http.StatusOK},
+		{"blank", http.StatusOK},
+		{"404", http.StatusNotFound},
+		{"https://example.com/default.png", http.StatusFound},
+		{"http://EXAMPLE.com:8080/default.png", http.StatusFound},
+		{"https://example.org/default.png", http.StatusBadRequest},
+		{"https://example.com.evil.org/default.png", http.StatusBadRequest},
+		{"https://evil.org/?example.com", http.StatusBadRequest},
+		{"ftp://example.com/default.png", http.StatusBadRequest},
+		{"nonsense", http.StatusBadRequest},
+	}
+
+	for _, tc := range tests {
+		target := server.AvatarPath + key + "?d=" + url.QueryEscape(tc.d)
+
+		w := do(s, http.MethodGet, target, "")
+		if w.Code != tc.want {
+			t.Errorf("GET %v gave status %v; want %v: %s", target, w.Code, tc.want, w.Body)
+			continue
+		}
+		if w.Code == http.StatusFound {
+			if loc := w.Header().Get("Location"); loc != tc.d {
+				t.Errorf("GET %v redirected to %q; want %q", target, loc, tc.d)
+			}
+		}
+	}
+}
+
+func TestAvatarNoRedirects(t *testing.T) {
+	s := testServer(t)
+
+	target := server.AvatarPath + avatar.MD5Key("gopher@example.com") + "?d=" + url.QueryEscape("https://example.com/default.png")
+
+	if w := do(s, http.MethodGet, target, ""); w.Code != http.StatusBadRequest {
+		t.Fatalf("GET %v gave status %v; want %v", target, w.Code, http.StatusBadRequest)
+	}
+}
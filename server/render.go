@@ -0,0 +1,301 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package server
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/myitcv/gopherize.me/compositor"
+	"github.com/myitcv/gopherize.me/export"
+	"github.com/myitcv/gopherize.me/gopher"
+)
+
+const (
+	// RenderPath is the URL path beneath which gophers are rendered, e.g.
+	// /render/1.body-blue_gopher.eyes-eyes.png
+	RenderPath = "/render/"
+
+	sizeParam   = "size"
+	bgParam     = "bg"
+	presetParam = "preset"
+	squareParam = "square"
+	filterParam = "filter"
+
+	pngExt = ".png"
+	icoExt = ".ico"
+	oraExt = ".ora"
+	svgExt = ".svg"
+
+	// a given recipe of a given version of the artwork always renders the
+	// same image, but the URL of a render does not include the version, so
+	// renders are cached for a day and then revalidated against their ETag
+	renderCacheControl = "public, max-age=86400"
+)
+
+// renderOpts are the options of a single render request
+type renderOpts struct {
+	recipe gopher.Recipe
+	compositor.Options
+
+	// ext is the extension of the requested format; see renderFormats
+	ext string
+}
+
+// renderFormat is a format in which gophers can be rendered
+type renderFormat struct {
+	contentType string
+	write       func(w io.Writer, c *compositor.Compositor, opts *renderOpts) error
+}
+
+// renderFormats are the formats in which gophers can be rendered, by
+// extension
+var renderFormats = map[string]renderFormat{
+	pngExt: {
+		contentType: "image/png",
+		write: func(w io.Writer, c *compositor.Compositor, opts *renderOpts) error {
+			img, err := c.Render(opts.recipe, opts.Options)
+			if err != nil {
+				return err
+			}
+			return export.PNG(w, img, opts.recipe, c.Manifest().Version)
+		},
+	},
+
+	// a favicon always contains export.FaviconSizes, so size, square and
+	// preset do not apply
+	icoExt: {
+		contentType: "image/x-icon",
+		write: func(w io.Writer, c *compositor.Compositor, opts *renderOpts) error {
+			var img image.Image
+			img, err := c.Composite(opts.recipe)
+			if err != nil {
+				return err
+			}
+			if opts.Background != nil {
+				img = compositor.Background(img, opts.Background)
+			}
+			return export.ICO(w, img, export.FaviconSizes, opts.Filter)
+		},
+	},
+
+	// layered images are always full-size; an SVG can be scaled by whatever
+	// displays it
+	oraExt: {
+		contentType: "image/openraster",
+		write: func(w io.Writer, c *compositor.Compositor, opts *renderOpts) error {
+			ls, err := c.Layers(opts.recipe)
+			if err != nil {
+				return err
+			}
+			return export.ORA(w, ls, opts.Background)
+		},
+	},
+	svgExt: {
+		contentType: "image/svg+xml",
+		write: func(w io.Writer, c *compositor.Compositor, opts *renderOpts) error {
+			ls, err := c.Layers(opts.recipe)
+			if err != nil {
+				return err
+			}
+			return export.SVG(w, ls, opts.recipe, opts.Background)
+		},
+	},
+}
+
+// render serves RenderPath. The last element of the path is the recipe
+// followed by the extension of one of renderFormats. The query parameters
+// are:
+//
+//	size    scale the gopher to fit within a square of that many pixels
+//	square  if true, pad the gopher to a square
+//	preset  the name of one of compositor.Presets, which sets size and square
+//	filter  the scaling filter, catmullrom (the default) or box
+//	bg      a background colour, as hex RGB, RRGGBB or RRGGBBAA
+//
+// size and square override the values set by preset.
+func (s *Server) render(w http.ResponseWriter, r *http.Request) {
+	if !allowGet(w, r) {
+		return
+	}
+
+	name := strings.TrimPrefix(r.URL.Path, RenderPath)
+	ext := path.Ext(name)
+	if _, ok := renderFormats[ext]; !ok || strings.Contains(name, "/") {
+		http.NotFound(w, r)
+		return
+	}
+
+	opts, status, err := s.parseRender(strings.TrimSuffix(name, ext), r)
+	if err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+	opts.ext = ext
+
+	s.serveRender(w, r, opts, renderCacheControl)
+}
+
+// serveRender renders opts in the requested format, setting an ETag and the given
+// Cache-Control header, and honouring If-None-Match
+func (s *Server) serveRender(w http.ResponseWriter, r *http.Request, opts *renderOpts, cacheControl string) {
+	etag := s.renderETag(opts)
+
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", cacheControl)
+
+	if etagMatch(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	rf := renderFormats[opts.ext]
+
+	buf := new(bytes.Buffer)
+	if err := rf.write(buf, s.compositor, opts); err != nil {
+		w.Header().Del("ETag")
+		w.Header().Del("Cache-Control")
+		http.Error(w, fmt.Sprintf("could not render gopher: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	serveBody(w, r, rf.contentType, buf)
+}
+
+// servePNG encodes img as the PNG body of the response
+func servePNG(w http.ResponseWriter, r *http.Request, img image.Image) {
+	buf := new(bytes.Buffer)
+	if err := png.Encode(buf, img); err != nil {
+		w.Header().Del("ETag")
+		w.Header().Del("Cache-Control")
+		http.Error(w, fmt.Sprintf("could not encode image: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	serveBody(w, r, "image/png", buf)
+}
+
+// serveBody writes buf as the body of the response
+func serveBody(w http.ResponseWriter, r *http.Request, contentType string, buf *bytes.Buffer) {
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Length", strconv.Itoa(buf.Len()))
+
+	if r.Method == http.MethodHead {
+		return
+	}
+
+	w.Write(buf.Bytes())
+}
+
+// parseRender parses the recipe enc and the query parameters of r, returning
+// the HTTP status to use if they are invalid
+func (s *Server) parseRender(enc string, r *http.Request) (*renderOpts, int, error) {
+	rec, err := gopher.Decode(enc)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	if err := rec.Validate(s.manifest); err != nil {
+		return nil, http.StatusNotFound, err
+	}
+
+	// the recipe may predate rules added since
+	res := &renderOpts{
+		recipe: rec.Resolve(s.manifest, gopher.Ref{}),
+	}
+
+	q := r.URL.Query()
+
+	if v := q.Get(presetParam); v != "" {
+		p, ok := compositor.LookupPreset(v)
+		if !ok {
+			return nil, http.StatusBadRequest, fmt.Errorf("unknown %v %q; expected one of %v", presetParam, v, compositor.PresetNames())
+		}
+		res.Options = p.Options()
+	}
+
+	if v := q.Get(squareParam); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, http.StatusBadRequest, fmt.Errorf("invalid %v %q", squareParam, v)
+		}
+		res.Square = b
+	}
+
+	if v := q.Get(filterParam); v != "" {
+		f, err := compositor.ParseFilter(v)
+		if err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+		res.Filter = f
+	}
+
+	if v := q.Get(sizeParam); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > compositor.MaxSize {
+			return nil, http.StatusBadRequest, fmt.Errorf("invalid %v %q; expected an integer between 1 and %v", sizeParam, v, compositor.MaxSize)
+		}
+		res.Size = n
+	}
+
+	if v := q.Get(bgParam); v != "" {
+		c, err := compositor.ParseColor(v)
+		if err != nil {
+			return nil, http.StatusBadRequest, fmt.Errorf("invalid %v: %v", bgParam, err)
+		}
+		res.Background = c
+	}
+
+	return res, 0, nil
+}
+
+// renderETag returns a strong entity tag for the render described by opts. It
+// includes the artwork version, so that changes to the artwork invalidate
+// cached renders.
+func (s *Server) renderETag(opts *renderOpts) string {
+	bg := "none"
+	if opts.Background != nil {
+		r, g, b, a := opts.Background.RGBA()
+		bg = fmt.Sprintf("%04x%04x%04x%04x", r, g, b, a)
+	}
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%v\x00%v\x00%v\x00%v\x00%v\x00%v", opts.recipe.Encode(), opts.ext, opts.Size, opts.Square, opts.Filter, bg)
+
+	return `"` + s.manifest.Version + "-" + hex.EncodeToString(h.Sum(nil))[:16] + `"`
+}
+
+// allowGet reports whether r is a GET or HEAD request, responding with an
+// error if not
+func allowGet(w http.ResponseWriter, r *http.Request) bool {
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		return true
+	}
+
+	w.Header().Set("Allow", "GET, HEAD")
+	http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+
+	return false
+}
+
+// etagMatch reports whether the If-None-Match header value inm matches etag
+func etagMatch(inm, etag string) bool {
+	for _, v := range strings.Split(inm, ",") {
+		v = strings.TrimSpace(v)
+		if v == "*" || strings.TrimPrefix(v, "W/") == etag {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+// Package server serves the gopherize.me client and its artwork over HTTP,
+// for local development and self-hosting, along with APIs that render
+// gophers and avatars as PNG images.
+package server
+
+import (
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/myitcv/gopherize.me/avatar"
+	"github.com/myitcv/gopherize.me/compositor"
+	"github.com/myitcv/gopherize.me/gopher"
+)
+
+const (
+	// ArtworkPath is the URL path beneath which the artwork is served. It
+	// matches the client's default artwork base.
+	ArtworkPath = "/artwork/"
+)
+
+// Server serves the client app and the artwork it displays
+type Server struct {
+	mux        *http.ServeMux
+	manifest   *gopher.Manifest
+	compositor *compositor.Compositor
+
+	pool     avatar.Pool
+	registry avatar.Registry
+
+	// redirectHosts are the hosts to which the avatar default may redirect
+	redirectHosts map[string]bool
+}
+
+// New returns a Server that serves the client app from the directory client
+// and the artwork described by m from the directory artworkDir, beneath
+// ArtworkPath. Gophers are rendered beneath RenderPath and avatars are served
+// beneath AvatarPath.
+func New(client, artworkDir string, m *gopher.Manifest) *Server {
+	s := &Server{
+		mux:        http.NewServeMux(),
+		manifest:   m,
+		compositor: compositor.New(artworkDir, m),
+	}
+
+	s.mux.Handle(ArtworkPath, http.StripPrefix(ArtworkPath, pngOnly(http.FileServer(http.Dir(artworkDir)))))
+	s.mux.HandleFunc(RenderPath, s.render)
+	s.mux.HandleFunc(AvatarPath, s.avatar)
+	s.mux.Handle("/", http.FileServer(http.Dir(client)))
+
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// pngOnly restricts h to serving PNG images, so that the Go source and
+// any other files that live alongside the artwork are not exposed
+func pngOnly(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.EqualFold(path.Ext(r.URL.Path), ".png") {
+			http.NotFound(w, r)
+			return
+		}
+
+		h.ServeHTTP(w, r)
+	})
+}
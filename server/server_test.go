@@ -0,0 +1,190 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package server_test
+
+import (
+	"bytes"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/myitcv/gopherize.me/artwork"
+	"github.com/myitcv/gopherize.me/server"
+)
+
+const (
+	artworkRoot = "../artwork"
+
+	testRecipe = "1.body-blue_gopher.eyes-eyes"
+)
+
+// testServer returns a server of the real artwork
+func testServer(t *testing.T) *server.Server {
+	t.Helper()
+
+	return server.New(t.TempDir(), artworkRoot, artwork.Default)
+}
+
+// do makes a request of s, with the given If-None-Match header if it is not
+// empty
+func do(s *server.Server, method, target, inm string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, target, nil)
+	if inm != "" {
+		r.Header.Set("If-None-Match", inm)
+	}
+
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, r)
+
+	return w
+}
+
+func TestRender(t *testing.T) {
+	s := testServer(t)
+
+	w := do(s, http.MethodGet, server.RenderPath+testRecipe+".png?size=64&square=1", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET gave status %v; want %v: %s", w.Code, http.StatusOK, w.Body)
+	}
+
+	if ct := w.Header().Get("Content-Type"); ct != "image/png" {
+		t.Fatalf("Content-Type = %q; want %q", ct, "image/png")
+	}
+
+	img, err := png.Decode(bytes.NewReader(w.Body.Bytes()))
+	if err != nil {
+		t.Fatalf("could not decode render: %v", err)
+	}
+	if b := img.Bounds(); b.Dx() != 64 || b.Dy() != 64 {
+		t.Fatalf("render is %vx%v; want 64x64", b.Dx(), b.Dy())
+	}
+}
+
+func TestRenderErrors(t *testing.T) {
+	s := testServer(t)
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		want   int
+	}{
+		{"bad recipe", http.MethodGet, server.RenderPath + "1.body.png", http.StatusBadRequest},
+		{"bad version", http.MethodGet, server.RenderPath + "2.body-blue_gopher.png", http.StatusBadRequest},
+		{"size too small", http.MethodGet, server.RenderPath + testRecipe + ".png?size=0", http.StatusBadRequest},
+		{"size too large", http.MethodGet, server.RenderPath + testRecipe + ".png?size=100000", http.StatusBadRequest},
+		{"size not a number", http.MethodGet, server.RenderPath + testRecipe + ".png?size=big", http.StatusBadRequest},
+		{"bad bg", http.MethodGet, server.RenderPath + testRecipe + ".png?bg=fffffg", http.StatusBadRequest},
+		{"bad preset", http.MethodGet, server.RenderPath + testRecipe + ".png?preset=huge", http.StatusBadRequest},
+		{"bad filter", http.MethodGet, server.RenderPath + testRecipe + ".png?filter=blur", http.StatusBadRequest},
+		{"unknown option", http.MethodGet, server.RenderPath + "1.body-no_such_gopher.png", http.StatusNotFound},
+		{"unknown category", http.MethodGet, server.RenderPath + "1.no_such_category-blue_gopher.png", http.StatusNotFound},
+		{"unknown format", http.MethodGet, server.RenderPath + testRecipe + ".gif", http.StatusNotFound},
+		{"nested path", http.MethodGet, server.RenderPath + "x/" + testRecipe + ".png", http.StatusNotFound},
+		{"POST", http.MethodPost, server.RenderPath + testRecipe + ".png", http.StatusMethodNotAllowed},
+		{"DELETE", http.MethodDelete, server.RenderPath + testRecipe + ".png", http.StatusMethodNotAllowed},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			w := do(s, tc.method, tc.target, "")
+			if w.Code != tc.want {
+				t.Fatalf("%v %v gave status %v; want %v: %s", tc.method, tc.target, w.Code, tc.want, w.Body)
+			}
+
+			if w.Code == http.StatusMethodNotAllowed {
+				if a := w.Header().Get("Allow"); a != "GET, HEAD" {
+					t.Fatalf("Allow = %q; want %q", a, "GET, HEAD")
+				}
+			}
+		})
+	}
+}
+
+func TestRenderETag(t *testing.T) {
+	s := testServer(t)
+
+	target := server.RenderPath + testRecipe + ".png?size=32"
+
+	w := do(s, http.MethodGet, target, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET gave status %v; want %v: %s", w.Code, http.StatusOK, w.Body)
+	}
+
+	etag := w.Header().Get("ETag")
+	if etag == "" || !strings.HasPrefix(etag, `"`+artwork.Default.Version+"-") {
+		t.Fatalf("ETag = %q; want one beginning with the artwork version %v", etag, artwork.Default.Version)
+	}
+	if cc := w.Header().Get("Cache-Control"); cc == "" || strings.Contains(cc, "immutable") {
+		t.Fatalf("Cache-Control = %q; want one that allows revalidation", cc)
+	}
+
+	for _, inm := range []string{etag, "W/" + etag, `"other", ` + etag, "*"} {
+		w := do(s, http.MethodGet, target, inm)
+		if w.Code != http.StatusNotModified {
+			t.Fatalf("GET with If-None-Match %v gave status %v; want %v", inm, w.Code, http.StatusNotModified)
+		}
+		if w.Body.Len() != 0 {
+			t.Fatalf("GET with If-None-Match %v gave a body", inm)
+		}
+		if got := w.Header().Get("ETag"); got != etag {
+			t.Fatalf("GET with If-None-Match %v gave ETag %q; want %q", inm, got, etag)
+		}
+	}
+
+	if w := do(s, http.MethodGet, target, `"other"`); w.Code != http.StatusOK {
+		t.Fatalf("GET with a stale If-None-Match gave status %v; want %v", w.Code, http.StatusOK)
+	}
+
+	// a different render has a different tag
+	other := do(s, http.MethodGet, server.RenderPath+testRecipe+".png?size=33", "")
+	if other.Header().Get("ETag") == etag {
+		t.Fatalf("renders of different sizes have the same ETag %v", etag)
+	}
+}
+
+func TestRenderHead(t *testing.T) {
+	s := testServer(t)
+
+	target := server.RenderPath + testRecipe + ".png?size=32"
+
+	get := do(s, http.MethodGet, target, "")
+	head := do(s, http.MethodHead, target, "")
+
+	if head.Code != http.StatusOK {
+		t.Fatalf("HEAD gave status %v; want %v", head.Code, http.StatusOK)
+	}
+	if head.Body.Len() != 0 {
+		t.Fatalf("HEAD gave a body of %v bytes", head.Body.Len())
+	}
+
+	for _, h := range []string{"Content-Type", "Content-Length", "ETag", "Cache-Control"} {
+		if g, w := head.Header().Get(h), get.Header().Get(h); g != w {
+			t.Errorf("HEAD gave %v %q; want %q as for GET", h, g, w)
+		}
+	}
+}
+
+func TestArtwork(t *testing.T) {
+	s := testServer(t)
+
+	tests := []struct {
+		path string
+		want int
+	}{
+		{server.ArtworkPath + artwork.Default.Categories[0].Options[0].Image, http.StatusOK},
+		{server.ArtworkPath + "artwork.go", http.StatusNotFound},
+		{server.ArtworkPath + "gen_manifest_manifestGen.go", http.StatusNotFound},
+		{server.ArtworkPath + "rules.json", http.StatusNotFound},
+		{server.ArtworkPath + "no_such_image.png", http.StatusNotFound},
+	}
+
+	for _, tc := range tests {
+		if w := do(s, http.MethodGet, tc.path, ""); w.Code != tc.want {
+			t.Errorf("GET %v gave status %v; want %v", tc.path, w.Code, tc.want)
+		}
+	}
+}
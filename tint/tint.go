@@ -0,0 +1,196 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+// Package tint recolours the parts of an image drawn in one key colour, and
+// its shades, to another colour.
+//
+// Pixels are matched by hue, and saturation relative to the key colour, so
+// that shading, highlights and anti-aliased edges are recoloured along with
+// flat areas, while outlines, whites and differently coloured details are
+// left alone. The shading of each pixel relative to the key colour is kept.
+//
+// The package works on raw pixels rather than images, so that the client can
+// share it with the compositor without depending on the image packages.
+package tint
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+const (
+	// hueFull and hueNone are the distances, in degrees, from the hue of the
+	// key colour within which a pixel is fully recoloured, and beyond which
+	// it is not recoloured at all
+	hueFull = 10
+	hueNone = 20
+
+	// satNone, satFull, satFullMax and satNoneMax bound the saturation of a
+	// pixel relative to that of the key colour: it is recoloured fully
+	// between satFull and satFullMax, not at all outside satNone and
+	// satNoneMax, and partially in between
+	satNone    = 0.3
+	satFull    = 0.45
+	satFullMax = 1.6
+	satNoneMax = 2
+
+	// valNone and valFull bound the value of a pixel, below which its hue is
+	// too dark to be reliable
+	valNone = 0.08
+	valFull = 0.2
+)
+
+// RGB is an opaque colour
+type RGB [3]uint8
+
+// ParseHex parses a colour given as six hex digits, e.g. abc3d6
+func ParseHex(s string) (RGB, error) {
+	var res RGB
+
+	if len(s) != 6 {
+		return res, fmt.Errorf("invalid colour %q; expected RRGGBB", s)
+	}
+
+	for i := range res {
+		v, err := strconv.ParseUint(s[2*i:2*i+2], 16, 8)
+		if err != nil {
+			return res, fmt.Errorf("invalid colour %q; expected RRGGBB", s)
+		}
+		res[i] = uint8(v)
+	}
+
+	return res, nil
+}
+
+// Hex returns c as six lower-case hex digits
+func (c RGB) Hex() string {
+	return fmt.Sprintf("%02x%02x%02x", c[0], c[1], c[2])
+}
+
+// Pix recolours pix, the non-premultiplied RGBA pixels of an image, from the
+// key colour from to the colour to
+func Pix(pix []uint8, from, to RGB) {
+	if from == to {
+		return
+	}
+
+	fh, fs, fv := hsv(from)
+	th, ts, tv := hsv(to)
+
+	for i := 0; i+3 < len(pix); i += 4 {
+		if pix[i+3] == 0 {
+			continue
+		}
+
+		c := RGB{pix[i], pix[i+1], pix[i+2]}
+		h, s, v := hsv(c)
+
+		w := weight(hueDist(h, fh), s/math.Max(fs, 1e-6), v)
+		if w == 0 {
+			continue
+		}
+
+		nh := math.Mod(th+h-fh+360, 360)
+		nc := rgb(nh, rescale(s, fs, ts), rescale(v, fv, tv))
+
+		for j := range nc {
+			pix[i+j] = uint8(math.Floor(float64(c[j])*(1-w) + float64(nc[j])*w + 0.5))
+		}
+	}
+}
+
+// weight returns how fully a pixel with hue distance dh from the key colour,
+// saturation ratio rs to it and value v is recoloured
+func weight(dh, rs, v float64) float64 {
+	return ramp(dh, hueNone, hueFull) *
+		math.Min(ramp(rs, satNone, satFull), ramp(rs, satNoneMax, satFullMax)) *
+		ramp(v, valNone, valFull)
+}
+
+// ramp is 0 at none, 1 at full and linear in between
+func ramp(x, none, full float64) float64 {
+	t := (x - none) / (full - none)
+	return math.Max(0, math.Min(1, t))
+}
+
+// rescale maps x in [0, 1] piecewise linearly so that 0, from and 1 become 0,
+// to and 1
+func rescale(x, from, to float64) float64 {
+	switch {
+	case x <= from:
+		if from == 0 {
+			return to
+		}
+		return x * to / from
+	case from >= 1:
+		return to
+	}
+
+	return to + (x-from)*(1-to)/(1-from)
+}
+
+func hueDist(a, b float64) float64 {
+	d := math.Abs(a - b)
+	if d > 180 {
+		d = 360 - d
+	}
+	return d
+}
+
+// hsv returns the hue, in degrees, saturation and value of c
+func hsv(c RGB) (h, s, v float64) {
+	r, g, b := float64(c[0])/255, float64(c[1])/255, float64(c[2])/255
+
+	mx := math.Max(r, math.Max(g, b))
+	mn := math.Min(r, math.Min(g, b))
+	d := mx - mn
+
+	v = mx
+	if mx > 0 {
+		s = d / mx
+	}
+	if d == 0 {
+		return 0, s, v
+	}
+
+	switch mx {
+	case r:
+		h = math.Mod((g-b)/d+6, 6)
+	case g:
+		h = (b-r)/d + 2
+	default:
+		h = (r-g)/d + 4
+	}
+
+	return h * 60, s, v
+}
+
+// rgb is the inverse of hsv
+func rgb(h, s, v float64) RGB {
+	c := v * s
+	x := c * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	m := v - c
+
+	var r, g, b float64
+	switch {
+	case h < 60:
+		r, g, b = c, x, 0
+	case h < 120:
+		r, g, b = x, c, 0
+	case h < 180:
+		r, g, b = 0, c, x
+	case h < 240:
+		r, g, b = 0, x, c
+	case h < 300:
+		r, g, b = x, 0, c
+	default:
+		r, g, b = c, 0, x
+	}
+
+	return RGB{byte8(r + m), byte8(g + m), byte8(b + m)}
+}
+
+func byte8(x float64) uint8 {
+	return uint8(math.Floor(math.Max(0, math.Min(1, x))*255 + 0.5))
+}
@@ -0,0 +1,159 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package tint
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseHex(t *testing.T) {
+	tests := []struct {
+		s    string
+		want RGB
+		ok   bool
+	}{
+		{"abc3d6", RGB{0xab, 0xc3, 0xd6}, true},
+		{"ABC3D6", RGB{0xab, 0xc3, 0xd6}, true},
+		{"000000", RGB{}, true},
+		{"ffffff", RGB{0xff, 0xff, 0xff}, true},
+		{"", RGB{}, false},
+		{"abc", RGB{}, false},
+		{"#abc3d6", RGB{}, false},
+		{"abc3d6ff", RGB{}, false},
+		{"abc3dg", RGB{}, false},
+		{"+1c3d6", RGB{}, false},
+	}
+
+	for _, tc := range tests {
+		got, err := ParseHex(tc.s)
+		switch {
+		case tc.ok && err != nil:
+			t.Errorf("ParseHex(%q) failed: %v", tc.s, err)
+		case !tc.ok && err == nil:
+			t.Errorf("ParseHex(%q) = %v; want error", tc.s, got)
+		case tc.ok && got != tc.want:
+			t.Errorf("ParseHex(%q) = %v; want %v", tc.s, got, tc.want)
+		}
+
+		if tc.ok {
+			if h := got.Hex(); h != strings.ToLower(tc.s) {
+				t.Errorf("ParseHex(%q).Hex() = %q; want %q", tc.s, h, strings.ToLower(tc.s))
+			}
+		}
+	}
+}
+
+func TestHSV(t *testing.T) {
+	for _, c := range []RGB{{0, 0, 0}, {0xff, 0xff, 0xff}, {0xab, 0xc3, 0xd6}, {0xff, 0x88, 0x00}, {0x12, 0xfe, 0x34}, {0x80, 0x00, 0x80}} {
+		if got := rgb(hsv(c)); got != c {
+			t.Errorf("rgb(hsv(%v)) = %v", c, got)
+		}
+	}
+}
+
+func TestPix(t *testing.T) {
+	var (
+		blue   = RGB{0xab, 0xc3, 0xd6}
+		orange = RGB{0xff, 0x88, 0x00}
+	)
+
+	// a shade of the key colour: the same hue and saturation, darker
+	bh, bs, bv := hsv(blue)
+	shade := rgb(bh, bs, bv*0.7)
+
+	// a pixel whose hue is close enough to the key colour to be recoloured
+	// only in part
+	near := rgb(bh+15, bs, bv)
+
+	tests := []struct {
+		name string
+		c    RGB
+		a    uint8
+
+		// recoloured is whether the pixel should be fully recoloured,
+		// partly recoloured or left alone
+		recoloured string
+	}{
+		{"key colour", blue, 0xff, "fully"},
+		{"shade", shade, 0xff, "fully"},
+		{"translucent", blue, 0x40, "fully"},
+		{"near hue", near, 0xff, "partly"},
+		{"other hue", RGB{0xff, 0xca, 0xca}, 0xff, "not"},
+		{"outline", RGB{0x00, 0x00, 0x00}, 0xff, "not"},
+		{"white", RGB{0xff, 0xff, 0xff}, 0xff, "not"},
+		{"grey", RGB{0x80, 0x80, 0x80}, 0xff, "not"},
+		{"transparent", blue, 0x00, "not"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pix := []uint8{tc.c[0], tc.c[1], tc.c[2], tc.a}
+			Pix(pix, blue, orange)
+
+			got := RGB{pix[0], pix[1], pix[2]}
+
+			if pix[3] != tc.a {
+				t.Fatalf("Pix changed alpha from %v to %v", tc.a, pix[3])
+			}
+
+			gh, _, _ := hsv(got)
+			oh, _, _ := hsv(orange)
+
+			switch tc.recoloured {
+			case "fully":
+				if d := hueDist(gh, oh); d > 2 {
+					t.Fatalf("Pix recoloured %v to %v, of hue %.0f; want the hue of %v, %.0f", tc.c, got, gh, orange, oh)
+				}
+			case "partly":
+				if got == tc.c {
+					t.Fatalf("Pix left %v alone; want it recoloured in part", tc.c)
+				}
+				if d := hueDist(gh, oh); d < 2 {
+					t.Fatalf("Pix recoloured %v fully to %v; want it recoloured in part", tc.c, got)
+				}
+			case "not":
+				if got != tc.c {
+					t.Fatalf("Pix recoloured %v to %v; want it left alone", tc.c, got)
+				}
+			}
+		})
+	}
+}
+
+func TestPixShading(t *testing.T) {
+	blue := RGB{0xab, 0xc3, 0xd6}
+	orange := RGB{0xff, 0x88, 0x00}
+
+	// the key colour becomes the target, and a darker shade stays darker
+	h, s, v := hsv(blue)
+	pix := []uint8{blue[0], blue[1], blue[2], 0xff}
+	shade := rgb(h, s, v*0.5)
+	pix = append(pix, shade[0], shade[1], shade[2], 0xff)
+
+	Pix(pix, blue, orange)
+
+	if got := (RGB{pix[0], pix[1], pix[2]}); got != orange {
+		t.Errorf("Pix recoloured the key colour to %v; want %v", got, orange)
+	}
+
+	_, _, kv := hsv(RGB{pix[0], pix[1], pix[2]})
+	_, _, sv := hsv(RGB{pix[4], pix[5], pix[6]})
+	if sv >= kv {
+		t.Errorf("Pix recoloured a shade to a value of %v, no darker than the key colour's %v", sv, kv)
+	}
+}
+
+func TestPixSameColour(t *testing.T) {
+	blue := RGB{0xab, 0xc3, 0xd6}
+
+	pix := []uint8{0xab, 0xc3, 0xd6, 0xff, 0x10, 0x20, 0x30, 0x80}
+	want := string(pix)
+
+	Pix(pix, blue, blue)
+
+	if string(pix) != want {
+		t.Errorf("Pix to the key colour itself changed %v to %v", []byte(want), pix)
+	}
+}